@@ -0,0 +1,84 @@
+/*
+Copyright © 2021 Denis Belyatsky <denis.bel@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/Brialius/jira2trello/internal/app"
+	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"github.com/spf13/viper"
+	"log"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+)
+
+var cleanupYes bool
+
+// cleanupCmd represents the cleanup command.
+var cleanupCmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "Clean up duplicate and orphaned cards",
+	Long: "Find duplicate cards, cards of missing Jira issues and cards named like Jira issue without Jira label, " +
+		"and archive or relabel them, members and labels of archived duplicates are copied to the kept card",
+	Run: func(cmd *cobra.Command, args []string) {
+		var jCfg jira.Config
+		if err := viper.UnmarshalKey("jira", &jCfg); err != nil {
+			log.Fatalf("Can't parse Jira config: %s", err)
+		}
+
+		var tCfg trello.Config
+		if err := viper.UnmarshalKey("trello", &tCfg); err != nil {
+			log.Fatalf("Can't parse Trello config: %s", err)
+		}
+
+		tCfg.Debug, jCfg.Debug = Debug, Debug
+
+		app.Cleanup(cmd.Context(), jira.NewClient(&jCfg), trello.NewClient(&tCfg), confirmCleanup)
+	},
+}
+
+func confirmCleanup(action *app.CleanupAction) bool {
+	if cleanupYes && action.Unverified {
+		slog.Warn("Skipping action, run without --yes to confirm it", "action", action.String())
+
+		return false
+	}
+
+	if cleanupYes {
+		return true
+	}
+
+	apply := false
+	if err := survey.AskOne(&survey.Confirm{Message: action.String() + "?"}, &apply); err != nil {
+		log.Fatalf("Can't get answer: %s", err)
+	}
+
+	return apply
+}
+
+func init() {
+	rootCmd.AddCommand(cleanupCmd)
+	cleanupCmd.Flags().BoolVarP(&cleanupYes, "yes", "y", false,
+		"apply actions without confirmation, except archiving cards of issues not found in Jira and cards without key")
+}
@@ -111,11 +111,11 @@ var configureCmd = &cobra.Command{
 
 		tCli := trello.NewClient(&tCfg)
 
-		if err := tCli.Connect(); err != nil {
+		if err := tCli.Connect(cmd.Context()); err != nil {
 			log.Fatalf("Can't connect to trello: %s", err)
 		}
 
-		userID, err := tCli.GetSelfMemberID()
+		userID, err := tCli.GetSelfMemberID(cmd.Context())
 		if err != nil {
 			log.Fatalf("can't get self id: %s", err)
 		}
@@ -124,7 +124,7 @@ var configureCmd = &cobra.Command{
 
 		viper.Set("trello.userid", tCfg.UserID)
 
-		boards, err := tCli.GetBoards()
+		boards, err := tCli.GetBoards(cmd.Context())
 		if err != nil {
 			log.Fatalf("Can't get trello boards: %s", err)
 		}
@@ -145,13 +145,13 @@ var configureCmd = &cobra.Command{
 
 		tCfg.Board = boards[board].ID
 
-		if err := tCli.SetBoard(); err != nil {
+		if err := tCli.SetBoard(cmd.Context()); err != nil {
 			log.Fatalf("Can't set trello board: %s", err)
 		}
 
 		viper.Set("trello.board", &tCfg.Board)
 
-		lists, err := tCli.GetLists()
+		lists, err := tCli.GetLists(cmd.Context())
 		if err != nil {
 			log.Fatalf("Can't get trello lists: %s", err)
 		}
@@ -207,7 +207,7 @@ var configureCmd = &cobra.Command{
 
 		viper.Set("trello.lists", &tCfg.Lists)
 
-		labels, err := tCli.GetLabels()
+		labels, err := tCli.GetLabels(cmd.Context())
 		if err != nil {
 			log.Fatalf("Can't get trello labels: %s", err)
 		}
@@ -0,0 +1,42 @@
+/*
+Copyright © 2021 Denis Belyatsky <denis.bel@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"github.com/Brialius/jira2trello/internal/app"
+	"github.com/spf13/cobra"
+)
+
+// addDateRangeFlags adds report period flags to the command.
+func addDateRangeFlags(cmd *cobra.Command, opts *app.DateRangeOptions) {
+	cmd.Flags().StringVar(&opts.Week, "week", "", "ISO week to build report for, e.g. 2026-W41")
+	cmd.Flags().StringVar(&opts.From, "from", "", "first day of report period, e.g. 2026-10-01")
+	cmd.Flags().StringVar(&opts.To, "to", "", "last day of report period, e.g. 2026-10-14")
+	cmd.Flags().BoolVar(&opts.LastSprint, "last-sprint", false,
+		"build report for the last closed sprint of jira.boardID board")
+
+	cmd.MarkFlagsMutuallyExclusive("week", "from")
+	cmd.MarkFlagsMutuallyExclusive("week", "to")
+	cmd.MarkFlagsMutuallyExclusive("week", "last-sprint")
+	cmd.MarkFlagsMutuallyExclusive("from", "last-sprint")
+	cmd.MarkFlagsMutuallyExclusive("to", "last-sprint")
+}
@@ -22,23 +22,31 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"fmt"
 	"github.com/Brialius/jira2trello/internal/app"
 	"github.com/Brialius/jira2trello/internal/jira"
 	"github.com/Brialius/jira2trello/internal/trello"
 	"github.com/spf13/viper"
 	"log"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
 
 var reportHTML bool
 var reportWeekly bool
+var reportFormat string
+var reportTemplate string
+var reportRange app.DateRangeOptions
+var reportArchiveDone bool
+var reportTeam bool
 
 // reportCmd represents the report command.
 var reportCmd = &cobra.Command{
 	Use:   "report",
 	Short: "Report based on trello cards",
-	Long:  "Report based on trello cards",
+	Long: "Report based on current trello cards, report period (--week, --from/--to or --last-sprint) " +
+		"can be set only for --weekly report based on jira tasks",
 	Run: func(cmd *cobra.Command, args []string) {
 		var tCfg trello.Config
 		var jCfg jira.Config
@@ -50,16 +58,68 @@ var reportCmd = &cobra.Command{
 			log.Fatalf("Can't parse Jira config: %s", err)
 		}
 
+		var jql app.JQLConfig
+		if err := viper.UnmarshalKey("jql", &jql); err != nil {
+			log.Fatalf("Can't parse JQL config: %s", err)
+		}
+
 		tCfg.Debug = Debug
 
-		app.Report(trello.NewClient(&tCfg), jira.NewClient(&jCfg), viper.GetString("jira.url"), reportHTML, reportWeekly)
+		if reportHTML {
+			reportFormat = "html"
+		}
+
+		var team []app.TeamMember
+
+		if reportTeam {
+			if err := viper.UnmarshalKey("team", &team); err != nil {
+				log.Fatalf("Can't parse team config: %s", err)
+			}
+
+			if len(team) == 0 {
+				log.Fatalf("Team members are not configured, add `team` list to config file")
+			}
+		}
+
+		app.Report(cmd.Context(), trello.NewClient(&tCfg), jira.NewClient(&jCfg), viper.GetString("jira.url"),
+			app.ReportOptions{
+				Format:      reportFormat,
+				Weekly:      reportWeekly,
+				Template:    reportTemplatePath(cmd, reportTemplate),
+				Range:       reportRange,
+				ArchiveDone: reportArchiveDone,
+				Team:        team,
+				JQL:         jql,
+				Output:      Output,
+			})
 	},
 }
 
+// reportTemplatePath returns template of the report, configured template is used only if neither template
+// nor format is set by flags, so explicit format isn't overridden by the config.
+func reportTemplatePath(cmd *cobra.Command, template string) string {
+	if template != "" || cmd.Flags().Changed("format") || cmd.Flags().Changed("html") {
+		return template
+	}
+
+	return viper.GetString("report.template")
+}
+
 func init() {
 	rootCmd.AddCommand(reportCmd)
 	reportCmd.Flags().BoolVar(&reportHTML, "html", false,
-		"generate html report and archive done cards")
+		"generate html report, same as --format html")
+	reportCmd.Flags().BoolVar(&reportArchiveDone, "archive-done", false,
+		"archive cards in done list after report is saved to file, archived cards are added to manifest file of the period")
+	reportCmd.Flags().StringVar(&reportFormat, "format", "text",
+		fmt.Sprintf("report format (%s), text and slack are printed to stdout, others are saved to file",
+			strings.Join(app.ReportFormats(), "|")))
+	reportCmd.Flags().StringVar(&reportTemplate, "template", "",
+		"path to report template (default is report.template config value, unless --format is set), "+
+			"*.html templates are executed with html/template, others with text/template")
+	reportCmd.Flags().BoolVar(&reportTeam, "team", false,
+		"generate report with section per team member from team config list")
+	addDateRangeFlags(reportCmd, &reportRange)
 	reportCmd.Flags().BoolVar(&reportWeekly, "weekly", false,
 		"generate weekly report based on jira tasks")
 }
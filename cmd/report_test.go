@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func Test_reportTemplatePath(t *testing.T) {
+	viper.Set("report.template", "config.tmpl")
+	defer viper.Set("report.template", nil)
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "config template", want: "config.tmpl"},
+		{name: "template flag", args: []string{"--template", "flag.tmpl"}, want: "flag.tmpl"},
+		{name: "format flag", args: []string{"--format", "csv"}, want: ""},
+		{name: "default format flag", args: []string{"--format", "text"}, want: ""},
+		{name: "html flag", args: []string{"--html"}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var template string
+
+			cmd := &cobra.Command{}
+			cmd.Flags().String("format", "text", "")
+			cmd.Flags().Bool("html", false, "")
+			cmd.Flags().StringVar(&template, "template", "", "")
+			require.NoError(t, cmd.Flags().Parse(tt.args))
+
+			require.Equal(t, tt.want, reportTemplatePath(cmd, template))
+		})
+	}
+}
@@ -22,24 +22,44 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"github.com/Brialius/jira2trello/internal/app"
+	"github.com/Brialius/jira2trello/internal/logging"
+	"github.com/Brialius/jira2trello/internal/theme"
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"log"
+	"log/slog"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
 )
 
 var (
-	cfgFile string
-	Debug   bool
-	Version string
+	cfgFile   string
+	Debug     bool
+	Version   string
+	Output    string
+	Theme     *theme.Theme
+	logOpts   logging.Options
+	closeLog  = func() error { return nil }
+	colorMode string
 )
 
+// jsonOutputCommands are commands, which support json output.
+var jsonOutputCommands = map[string]bool{"sync": true, "status": true, "report": true, "weekly-report": true}
+
 // rootCmd represents the base command when called without any subcommands.
 var rootCmd = &cobra.Command{
 	Use: "jira2trello",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if err := checkOutput(cmd.Name(), Output); err != nil {
+			log.Fatalf("Can't set output format: %s", err)
+		}
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -47,15 +67,69 @@ var rootCmd = &cobra.Command{
 func Execute(version string) {
 	Version = version
 
-	if err := rootCmd.Execute(); err != nil {
+	// Commands are canceled on interrupt, so requests in flight are aborted,
+	// the second interrupt terminates the program immediately.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	err := rootCmd.ExecuteContext(ctx)
+
+	stop()
+
+	_ = closeLog()
+
+	if err != nil {
 		log.Fatal(err)
 	}
 }
 
 func init() {
-	cobra.OnInitialize(initConfig)
+	cobra.OnInitialize(initLogging, initOutput, initConfig, initTheme)
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.jira2trello.yaml)")
-	rootCmd.PersistentFlags().BoolVar(&Debug, "debug", false, "write debug info to logs and files")
+	rootCmd.PersistentFlags().BoolVar(&Debug, "debug", false,
+		"write debug logs and API responses to files in user cache dir")
+	rootCmd.PersistentFlags().StringVar(&logOpts.Level, "log-level", "",
+		"log level: debug, info, warn or error (default is info, debug with --debug)")
+	rootCmd.PersistentFlags().StringVar(&logOpts.Format, "log-format", logging.FormatText, "log format: text or json")
+	rootCmd.PersistentFlags().StringVar(&logOpts.File, "log-file", "", "write logs to file instead of stderr")
+	rootCmd.PersistentFlags().StringVarP(&Output, "output", "o", app.OutputText,
+		"output format: text or json, json is supported by sync, status, report and weekly-report")
+	rootCmd.PersistentFlags().StringVar(&colorMode, "color", theme.ModeAuto,
+		"colorize output: auto, always or never, auto disables colors for non-terminal output and with NO_COLOR")
+}
+
+// initLogging sets up the default logger from flags.
+func initLogging() {
+	if logOpts.Level == "" && Debug {
+		logOpts.Level = "debug"
+	}
+
+	var err error
+
+	if closeLog, err = logging.Setup(logOpts); err != nil {
+		log.Fatalf("Can't set up logging: %s", err)
+	}
+}
+
+// initOutput validates output format flag.
+func initOutput() {
+	if err := app.ValidateOutput(Output); err != nil {
+		log.Fatalf("Can't set output format: %s", err)
+	}
+}
+
+// checkOutput returns error if command doesn't support output format, so it isn't silently ignored.
+func checkOutput(command, output string) error {
+	if output != app.OutputText && !jsonOutputCommands[command] {
+		return fmt.Errorf("`%s` command doesn't support %s output, it's supported by sync, status, report "+
+			"and weekly-report", command, output)
+	}
+
+	return nil
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -85,6 +159,23 @@ func initConfig() {
 
 	// If a config file is found, read it in.
 	if err := viper.ReadInConfig(); err == nil {
-		fmt.Printf("Using config file:%s\n\n", viper.ConfigFileUsed())
+		slog.Info("Using config file", "file", viper.ConfigFileUsed())
+	}
+}
+
+// initTheme sets up output colors from flag and theme config.
+func initTheme() {
+	var cfg theme.Config
+	if err := viper.UnmarshalKey("theme", &cfg); err != nil {
+		log.Fatalf("Can't parse theme config: %s", err)
+	}
+
+	enabled, err := theme.Enabled(colorMode, os.Stdout)
+	if err != nil {
+		log.Fatalf("Can't set color mode: %s", err)
+	}
+
+	if Theme, err = theme.New(cfg, enabled); err != nil {
+		log.Fatalf("Can't set up theme: %s", err)
 	}
 }
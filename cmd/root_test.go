@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/Brialius/jira2trello/internal/app"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_checkOutput(t *testing.T) {
+	tests := []struct {
+		command string
+		output  string
+		wantErr bool
+	}{
+		{command: "sync", output: app.OutputJSON},
+		{command: "weekly-report", output: app.OutputJSON},
+		{command: "cleanup", output: app.OutputText},
+		{command: "cleanup", output: app.OutputJSON, wantErr: true},
+		{command: "doctor", output: app.OutputJSON, wantErr: true},
+		{command: "tui", output: app.OutputJSON, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.command+" "+tt.output, func(t *testing.T) {
+			err := checkOutput(tt.command, tt.output)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright © 2021 Denis Belyatsky <denis.bel@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"github.com/Brialius/jira2trello/internal/app"
+	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/logging"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"github.com/spf13/viper"
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+var serveOptions app.ServeOptions
+var serveWebhookURL string
+
+// serveCmd represents the serve command.
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve report and sync status over HTTP",
+	Long: "Run HTTP server with live HTML report of cards at `/`, JSON status of the last sync at `/status`, " +
+		"`POST /sync` to start sync, Prometheus metrics at `/metrics` and `/healthz` health check, " +
+		"with --interval cards are synced periodically. " +
+		"With --webhook-url Trello and Jira webhooks are registered to sync changed cards right away",
+	Run: func(cmd *cobra.Command, args []string) {
+		var jCfg jira.Config
+		if err := viper.UnmarshalKey("jira", &jCfg); err != nil {
+			log.Fatalf("Can't parse Jira config: %s", err)
+		}
+
+		var tCfg trello.Config
+		if err := viper.UnmarshalKey("trello", &tCfg); err != nil {
+			log.Fatalf("Can't parse Trello config: %s", err)
+		}
+
+		var jql app.JQLConfig
+		if err := viper.UnmarshalKey("jql", &jql); err != nil {
+			log.Fatalf("Can't parse JQL config: %s", err)
+		}
+
+		tCfg.Debug, jCfg.Debug = Debug, Debug
+
+		if err := viper.UnmarshalKey("webhooks", &serveOptions.Webhooks); err != nil {
+			log.Fatalf("Can't parse webhooks config: %s", err)
+		}
+
+		if serveWebhookURL != "" {
+			serveOptions.Webhooks.URL = serveWebhookURL
+		}
+
+		logging.AddSecrets(serveOptions.Webhooks.Secret)
+
+		serveOptions.JiraURL = jCfg.URL
+
+		app.NewSyncService(jira.NewClient(&jCfg), trello.NewClient(&tCfg), jql, Theme,
+			app.TableOptions{}).Serve(cmd.Context(), serveOptions)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().StringVar(&serveOptions.Addr, "addr", ":8080", "address to listen on")
+	serveCmd.Flags().DurationVar(&serveOptions.Interval, "interval", 0,
+		"sync cards every interval, e.g. 15m, sync runs only on request if it's 0")
+	serveCmd.Flags().StringVar(&serveWebhookURL, "webhook-url", "",
+		"public URL of the server to register webhooks (default is webhooks.url config value)")
+}
@@ -31,6 +31,8 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var syncTable app.TableOptions
+
 // syncCmd represents the sync command.
 var syncCmd = &cobra.Command{
 	Use:   "sync",
@@ -47,12 +49,19 @@ var syncCmd = &cobra.Command{
 			log.Fatalf("Can't parse Trello config: %s", err)
 		}
 
+		var jql app.JQLConfig
+		if err := viper.UnmarshalKey("jql", &jql); err != nil {
+			log.Fatalf("Can't parse JQL config: %s", err)
+		}
+
 		tCfg.Debug, jCfg.Debug = Debug, Debug
 
-		app.NewSyncService(jira.NewClient(&jCfg), trello.NewClient(&tCfg)).Sync()
+		app.NewSyncService(jira.NewClient(&jCfg), trello.NewClient(&tCfg), jql, Theme,
+			withTerminalWidth(syncTable)).Sync(cmd.Context(), Output)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(syncCmd)
+	addTableFlags(syncCmd, &syncTable)
 }
@@ -0,0 +1,54 @@
+/*
+Copyright © 2021 Denis Belyatsky <denis.bel@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"github.com/Brialius/jira2trello/internal/app"
+	"golang.org/x/term"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// addTableFlags adds flags of Jira task table to the command.
+func addTableFlags(cmd *cobra.Command, opts *app.TableOptions) {
+	cmd.Flags().StringSliceVar(&opts.Columns, "columns", nil,
+		fmt.Sprintf("task table columns (%s), default is status,type,key,summary,created,due,spent",
+			strings.Join(app.TaskColumns(), "|")))
+	cmd.Flags().StringSliceVar(&opts.Sort, "sort", nil,
+		"columns to sort tasks by, column prefixed with - is sorted in descending order, e.g. due,-updated "+
+			"(default is created)")
+	cmd.Flags().StringSliceVar(&opts.Status, "status", nil, "show only tasks with any of the statuses")
+	cmd.Flags().StringSliceVar(&opts.Type, "type", nil, "show only tasks of any of the types")
+	cmd.Flags().BoolVar(&opts.Overdue, "overdue", false, "show only tasks with due date in the past")
+}
+
+// withTerminalWidth returns table options with stdout terminal width, width isn't set if stdout isn't a terminal.
+func withTerminalWidth(opts app.TableOptions) app.TableOptions {
+	if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+		opts.Width = width
+	}
+
+	return opts
+}
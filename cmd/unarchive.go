@@ -0,0 +1,57 @@
+/*
+Copyright © 2021 Denis Belyatsky <denis.bel@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"github.com/Brialius/jira2trello/internal/app"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"github.com/spf13/viper"
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+var unarchiveManifest string
+
+// unarchiveCmd represents the unarchive command.
+var unarchiveCmd = &cobra.Command{
+	Use:   "unarchive",
+	Short: "Restore cards archived by report",
+	Long:  "Restore cards archived by `report --archive-done` using manifest file saved next to the report",
+	Run: func(cmd *cobra.Command, args []string) {
+		var tCfg trello.Config
+		if err := viper.UnmarshalKey("trello", &tCfg); err != nil {
+			log.Fatalf("Can't parse Trello config: %s", err)
+		}
+
+		tCfg.Debug = Debug
+
+		app.Unarchive(cmd.Context(), trello.NewClient(&tCfg), unarchiveManifest)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(unarchiveCmd)
+	unarchiveCmd.Flags().StringVar(&unarchiveManifest, "from-manifest", "",
+		"manifest file with archived cards, e.g. jira2trello-archived-2026-41.json")
+	_ = unarchiveCmd.MarkFlagRequired("from-manifest")
+}
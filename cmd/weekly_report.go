@@ -30,11 +30,15 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var weeklyReportRange app.DateRangeOptions
+var weeklyReportTable app.TableOptions
+
 // weeklyReportCmd represents the weekly-report command.
 var weeklyReportCmd = &cobra.Command{
 	Use:   "weekly-report",
 	Short: "Weekly report based on jira query",
-	Long:  "Get `Closed` or `In progress` stories and jira tasks with non zero `timespent` for last 7 days",
+	Long: "Get `Closed` or `In progress` stories and jira tasks with non zero `timespent` for last 7 days, " +
+		"or for the period set by --week, --from/--to or --last-sprint",
 	Aliases: []string{
 		"weekly",
 	},
@@ -44,12 +48,20 @@ var weeklyReportCmd = &cobra.Command{
 			log.Fatalf("Can't parse Jira config: %s", err)
 		}
 
+		var jql app.JQLConfig
+		if err := viper.UnmarshalKey("jql", &jql); err != nil {
+			log.Fatalf("Can't parse JQL config: %s", err)
+		}
+
 		jCfg.Debug = Debug
 
-		app.WeeklyReport(jira.NewClient(&jCfg))
+		app.WeeklyReport(cmd.Context(), jira.NewClient(&jCfg), weeklyReportRange, jql, Output, Theme,
+			withTerminalWidth(weeklyReportTable))
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(weeklyReportCmd)
+	addDateRangeFlags(weeklyReportCmd, &weeklyReportRange)
+	addTableFlags(weeklyReportCmd, &weeklyReportTable)
 }
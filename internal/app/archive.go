@@ -0,0 +1,118 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"slices"
+	"time"
+)
+
+// archiveManifest keeps archived cards, so they can be restored with `unarchive` command.
+type archiveManifest struct {
+	ArchivedAt time.Time             `json:"archivedAt"`
+	Board      string                `json:"board"`
+	List       string                `json:"list"`
+	Cards      []archiveManifestCard `json:"cards"`
+}
+
+type archiveManifestCard struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// archiveDoneCards archives cards in `Done` list and saves their IDs to manifest file.
+// Manifest is saved even if archiving is interrupted, so already archived cards can be restored.
+// Cards archived by previous runs for the same period are kept in the manifest.
+func archiveDoneCards(ctx context.Context, tCli TrelloConnector, manifestFile string) error {
+	// Existing manifest is loaded before archiving, so its cards aren't lost if it can't be read.
+	manifest, err := loadArchiveManifest(manifestFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	cfg := tCli.GetConfig()
+	cards, archiveErr := tCli.ArchiveAllCardsInList(ctx, cfg.Lists.Done)
+
+	if len(cards) == 0 {
+		return archiveErr
+	}
+
+	manifest.ArchivedAt = time.Now()
+	manifest.Board = cfg.Board
+	manifest.List = cfg.Lists.Done
+
+	for _, card := range cards {
+		// Card may be restored and archived again for the same period.
+		if slices.ContainsFunc(manifest.Cards, func(c archiveManifestCard) bool { return c.ID == card.ID }) {
+			continue
+		}
+
+		manifest.Cards = append(manifest.Cards, archiveManifestCard{
+			ID:   card.ID,
+			Name: card.Name,
+		})
+	}
+
+	if err := writeFileAtomically(manifestFile, func(out io.Writer) error {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(manifest)
+	}); err != nil {
+		return fmt.Errorf("can't save archive manifest: %w", err)
+	}
+
+	slog.Info("Done cards archived", "count", len(cards), "manifest", manifestFile)
+
+	return archiveErr
+}
+
+// loadArchiveManifest reads manifest file, empty manifest is returned with the error if file doesn't exist.
+func loadArchiveManifest(manifestFile string) (*archiveManifest, error) {
+	manifest := &archiveManifest{Cards: make([]archiveManifestCard, 0)}
+
+	content, err := os.ReadFile(manifestFile)
+	if err != nil {
+		return manifest, fmt.Errorf("can't read file: %w", err)
+	}
+
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return nil, fmt.Errorf("can't parse file: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// Unarchive restores cards archived by report from manifest file.
+func Unarchive(ctx context.Context, tCli TrelloConnector, manifestFile string) {
+	manifest, err := loadArchiveManifest(manifestFile)
+	if err != nil {
+		log.Fatalf("can't load archive manifest: %s", err)
+	}
+
+	if err := tCli.Connect(ctx); err != nil {
+		log.Fatalf("Can't connect to trello: %s", err)
+	}
+
+	if err := unarchiveCards(ctx, tCli, manifest); err != nil {
+		log.Fatalf("can't unarchive cards: %s", err)
+	}
+}
+
+func unarchiveCards(ctx context.Context, tCli TrelloConnector, manifest *archiveManifest) error {
+	for _, card := range manifest.Cards {
+		if err := tCli.UnarchiveCard(ctx, card.ID); err != nil {
+			return fmt.Errorf("can't unarchive card `%s`: %w", card.Name, err)
+		}
+
+		slog.Info("Card restored", "card", card.Name)
+	}
+
+	return nil
+}
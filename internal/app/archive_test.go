@@ -0,0 +1,153 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"github.com/stretchr/testify/require"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_archiveDoneCards(t *testing.T) {
+	tests := []struct {
+		name       string
+		archived   []*trello.Card
+		archiveErr error
+		wantErr    bool
+		wantCards  []archiveManifestCard
+	}{
+		{
+			name: "valid",
+			archived: []*trello.Card{
+				{ID: "098098098098098098098021", Name: "JIRA1-1289 | Test task 1289"},
+				{ID: "098098098098098098098022", Name: "JIRA1-1290 | Test task 1290"},
+			},
+			wantCards: []archiveManifestCard{
+				{ID: "098098098098098098098021", Name: "JIRA1-1289 | Test task 1289"},
+				{ID: "098098098098098098098022", Name: "JIRA1-1290 | Test task 1290"},
+			},
+		},
+		{
+			name: "partially archived",
+			archived: []*trello.Card{
+				{ID: "098098098098098098098021", Name: "JIRA1-1289 | Test task 1289"},
+			},
+			archiveErr: errors.New("trello is unavailable"),
+			wantErr:    true,
+			wantCards: []archiveManifestCard{
+				{ID: "098098098098098098098021", Name: "JIRA1-1289 | Test task 1289"},
+			},
+		},
+		{
+			name:       "nothing archived",
+			archiveErr: errors.New("trello is unavailable"),
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tCli := GetTrelloMockedCli(nil)
+			tCli.ArchiveAllCardsInListFunc = func(ctx context.Context, listID string) ([]*trello.Card, error) {
+				return tt.archived, tt.archiveErr
+			}
+			tCli.UnarchiveCardFunc = func(ctx context.Context, cardID string) error {
+				return nil
+			}
+
+			manifestFile := filepath.Join(t.TempDir(), "manifest.json")
+
+			err := archiveDoneCards(context.Background(), tCli, manifestFile)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Len(t, tCli.ArchiveAllCardsInListCalls(), 1)
+			require.Equal(t, "12345678909876543219d1cf", tCli.ArchiveAllCardsInListCalls()[0].S)
+
+			if tt.wantCards == nil {
+				require.NoFileExists(t, manifestFile)
+
+				return
+			}
+
+			var manifest archiveManifest
+			mustLoadJSONFile(t, manifestFile, &manifest)
+			require.Equal(t, tt.wantCards, manifest.Cards)
+			require.Equal(t, "12345678909876543219d1cf", manifest.List)
+
+			require.NoError(t, unarchiveCards(context.Background(), tCli, &manifest))
+
+			unarchived := make([]string, 0)
+			for _, c := range tCli.UnarchiveCardCalls() {
+				unarchived = append(unarchived, c.S)
+			}
+
+			require.Len(t, unarchived, len(tt.wantCards))
+		})
+	}
+}
+
+func Test_archiveDoneCards_sameManifest(t *testing.T) {
+	runs := [][]*trello.Card{
+		{{ID: "098098098098098098098021", Name: "JIRA1-1289 | Test task 1289"}},
+		{
+			{ID: "098098098098098098098022", Name: "JIRA1-1290 | Test task 1290"},
+			{ID: "098098098098098098098021", Name: "JIRA1-1289 | Test task 1289"},
+		},
+	}
+
+	tCli := GetTrelloMockedCli(nil)
+	tCli.ArchiveAllCardsInListFunc = func(ctx context.Context, listID string) ([]*trello.Card, error) {
+		return runs[len(tCli.ArchiveAllCardsInListCalls())-1], nil
+	}
+
+	manifestFile := filepath.Join(t.TempDir(), "manifest.json")
+
+	for range runs {
+		require.NoError(t, archiveDoneCards(context.Background(), tCli, manifestFile))
+	}
+
+	manifest, err := loadArchiveManifest(manifestFile)
+	require.NoError(t, err)
+	require.Equal(t, []archiveManifestCard{
+		{ID: "098098098098098098098021", Name: "JIRA1-1289 | Test task 1289"},
+		{ID: "098098098098098098098022", Name: "JIRA1-1290 | Test task 1290"},
+	}, manifest.Cards)
+
+	require.NoError(t, os.WriteFile(manifestFile, []byte("broken"), 0600))
+	require.Error(t, archiveDoneCards(context.Background(), tCli, manifestFile))
+	require.Len(t, tCli.ArchiveAllCardsInListCalls(), len(runs))
+}
+
+func Test_writeFileAtomically(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "report.txt")
+
+	require.NoError(t, os.WriteFile(fileName, []byte("previous long report content"), 0600))
+
+	require.NoError(t, writeFileAtomically(fileName, func(w io.Writer) error {
+		_, err := w.Write([]byte("new report"))
+
+		return err
+	}))
+
+	content, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	require.Equal(t, "new report", string(content))
+
+	require.Error(t, writeFileAtomically(fileName, func(w io.Writer) error {
+		return errors.New("can't render report")
+	}))
+
+	content, err = os.ReadFile(fileName)
+	require.NoError(t, err)
+	require.Equal(t, "new report", string(content))
+
+	entries, err := os.ReadDir(filepath.Dir(fileName))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"log/slog"
+)
+
+func (s *SyncService) cardMarker(task *jira.Task) *trello.Marker {
+	return &trello.Marker{
+		Key:   task.Key,
+		Link:  task.Link,
+		Owner: s.tCli.GetConfig().UserID,
+	}
+}
+
+// updateCardMarker adds marker to cards created before markers were added,
+// on shared board it also sets current user as the card owner.
+func (s *SyncService) updateCardMarker(ctx context.Context, tCard *trello.Card, task *jira.Task) error {
+	marker := s.cardMarker(task)
+
+	switch {
+	case tCard.Marker == nil:
+		slog.Info("Linking card to Jira task", "key", tCard.Key)
+	case !s.tCli.GetConfig().Shared || *tCard.Marker == *marker:
+		return nil
+	}
+
+	if err := s.tCli.UpdateCardDesc(ctx, tCard.ID, trello.SetMarker(tCard.Desc, marker)); err != nil {
+		return fmt.Errorf("can't update marker on card `%s`: %w", tCard.Key, err)
+	}
+
+	tCard.Marker = marker
+
+	return nil
+}
@@ -0,0 +1,277 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"log"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+)
+
+const (
+	cleanupArchiveDuplicate = "archive duplicate"
+	cleanupArchive          = "archive"
+	cleanupRelabel          = "relabel"
+)
+
+// CleanupAction is a proposed fix of duplicate or orphaned card.
+type CleanupAction struct {
+	Kind   string
+	Card   *trello.Card
+	Reason string
+	// Into is the card, which is kept instead of archived duplicate card.
+	Into *trello.Card
+	// Unverified is set for cards of issues not found in Jira, they may be hidden from the user, and for cards
+	// without Jira key, they may be made by hand, so such actions are applied only if confirmed interactively.
+	Unverified bool
+}
+
+func (a *CleanupAction) String() string {
+	switch a.Kind {
+	case cleanupArchiveDuplicate:
+		dropped := ""
+		if lost := droppedData(a.Card, a.Into); lost != "" {
+			dropped = ", its " + lost + " will be lost"
+		}
+
+		return fmt.Sprintf("archive duplicate `%s` (%s) and copy its members and labels to %s%s: %s",
+			a.Card.Name, a.Card.ID, a.Into.ID, dropped, a.Reason)
+	case cleanupRelabel:
+		return fmt.Sprintf("add Jira label to `%s` (%s): %s", a.Card.Name, a.Card.ID, a.Reason)
+	default:
+		return fmt.Sprintf("archive `%s` (%s): %s", a.Card.Name, a.Card.ID, a.Reason)
+	}
+}
+
+// Cleanup finds duplicate cards, cards of missing Jira issues and Jira cards without Jira label,
+// actions are applied if confirm returns true.
+func Cleanup(ctx context.Context, jCli JiraConnector, tCli TrelloConnector, confirm func(action *CleanupAction) bool) {
+	if err := jCli.Connect(ctx); err != nil {
+		log.Fatalf("Can't connect to jira server: %s", err)
+	}
+
+	if err := tCli.Connect(ctx); err != nil {
+		log.Fatalf("Can't connect to trello: %s", err)
+	}
+
+	actions, err := cleanupActions(ctx, jCli, tCli)
+	if err != nil {
+		log.Fatalf("Can't find cards to clean up: %s", err)
+	}
+
+	if len(actions) == 0 {
+		fmt.Println("Nothing to clean up")
+
+		return
+	}
+
+	applied := 0
+
+	for _, action := range actions {
+		if !confirm(action) {
+			continue
+		}
+
+		if err := applyCleanupAction(ctx, tCli, action); err != nil {
+			log.Fatalf("Can't %s card `%s`: %s", action.Kind, action.Card.ID, err)
+		}
+
+		applied++
+	}
+
+	fmt.Printf("%d of %d actions applied\n", applied, len(actions))
+}
+
+func cleanupActions(ctx context.Context, jCli JiraConnector, tCli TrelloConnector) ([]*CleanupAction, error) {
+	slog.Info("Getting Trello cards")
+
+	cards, err := tCli.GetBoardCards(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can't get board cards: %w", err)
+	}
+
+	cards = userCards(cards, tCli.GetConfig())
+
+	slog.Info("Trello cards found", "count", len(cards))
+
+	keys := map[string]bool{}
+
+	for _, card := range cards {
+		if card.Key != "" {
+			keys[card.Key] = true
+		}
+	}
+
+	existing, err := jCli.GetExistingKeys(ctx, sortedKeys(keys))
+	if err != nil {
+		return nil, fmt.Errorf("can't check jira issues: %w", err)
+	}
+
+	return findCleanupActions(cards, existing, tCli.GetConfig().Labels.Jira), nil
+}
+
+// userCards returns cards of the user, cards owned by the user on shared board.
+func userCards(cards []*trello.Card, cfg *trello.Config) []*trello.Card {
+	res := make([]*trello.Card, 0, len(cards))
+
+	for _, card := range cards {
+		if cfg.Shared && card.OwnedBy(cfg.UserID) ||
+			!cfg.Shared && slices.Contains(strings.Split(card.IDMembers, ","), cfg.UserID) {
+			res = append(res, card)
+		}
+	}
+
+	return res
+}
+
+// findCleanupActions returns actions for duplicate and orphaned cards, the card with the most data is kept
+// of duplicates. Epic cards aren't cleaned up.
+func findCleanupActions(cards []*trello.Card, existing map[string]bool, jiraLabel string) []*CleanupAction {
+	res := make([]*CleanupAction, 0)
+	byKey := map[string][]*trello.Card{}
+
+	for _, card := range cards {
+		if card.Marker != nil && card.Marker.Type == trello.MarkerTypeEpic {
+			continue
+		}
+
+		labeled := card.IDLabels != nil && strings.Contains(strings.Join(*card.IDLabels, ","), jiraLabel)
+
+		switch {
+		case !labeled && card.Key != "" && existing[card.Key]:
+			res = append(res, &CleanupAction{Kind: cleanupRelabel, Card: card, Reason: "named like Jira issue"})
+		case !labeled:
+		case card.Key == "":
+			res = append(res, &CleanupAction{Kind: cleanupArchive, Card: card, Unverified: true,
+				Reason: "card has Jira label, but no Jira key"})
+		case !existing[card.Key]:
+			res = append(res, &CleanupAction{Kind: cleanupArchive, Card: card, Unverified: true,
+				Reason: fmt.Sprintf("%s doesn't exist in Jira or isn't visible to you", card.Key)})
+		default:
+			byKey[card.Key] = append(byKey[card.Key], card)
+		}
+	}
+
+	for _, key := range sortedKeys(byKey) {
+		duplicates := byKey[key]
+		if len(duplicates) < 2 {
+			continue
+		}
+
+		sort.Slice(duplicates, func(i, j int) bool {
+			return richerCard(duplicates[i], duplicates[j])
+		})
+
+		for _, card := range duplicates[1:] {
+			res = append(res, &CleanupAction{Kind: cleanupArchiveDuplicate, Card: card, Into: duplicates[0],
+				Reason: fmt.Sprintf("duplicate card of %s", key)})
+		}
+	}
+
+	return res
+}
+
+// richerCard returns true if card a has more checklist items, attachments, comments, labels or longer description
+// than card b. The older card is richer of equal ones, Trello card ID starts with creation timestamp.
+func richerCard(a, b *trello.Card) bool {
+	switch {
+	case a.Details != b.Details:
+		return a.Details > b.Details
+	case labelsCount(a) != labelsCount(b):
+		return labelsCount(a) > labelsCount(b)
+	case len(a.Desc) != len(b.Desc):
+		return len(a.Desc) > len(b.Desc)
+	}
+
+	return a.ID < b.ID
+}
+
+// droppedData returns description of the card data, which isn't copied to the kept card.
+func droppedData(card, into *trello.Card) string {
+	dropped := make([]string, 0, 2)
+
+	if card.Desc != "" && card.Desc != into.Desc {
+		dropped = append(dropped, "description")
+	}
+
+	if card.Details > 0 {
+		dropped = append(dropped, fmt.Sprintf("%d checklist items, attachments and comments", card.Details))
+	}
+
+	return strings.Join(dropped, " and ")
+}
+
+func labelsCount(card *trello.Card) int {
+	if card.IDLabels == nil {
+		return 0
+	}
+
+	return len(*card.IDLabels)
+}
+
+func applyCleanupAction(ctx context.Context, tCli TrelloConnector, action *CleanupAction) error {
+	switch action.Kind {
+	case cleanupArchiveDuplicate:
+		members := mergeMembers(action.Into.IDMembers, action.Card.IDMembers)
+		if members != action.Into.IDMembers {
+			if err := tCli.UpdateCardMembers(ctx, action.Into.ID, members); err != nil {
+				return fmt.Errorf("can't update members: %w", err)
+			}
+
+			action.Into.IDMembers = members
+		}
+
+		if labels := mergeLabels(action.Into.IDLabels, action.Card.IDLabels); len(labels) > labelsCount(action.Into) {
+			if err := tCli.UpdateCardLabels(ctx, action.Into.ID, strings.Join(labels, ",")); err != nil {
+				return fmt.Errorf("can't update labels: %w", err)
+			}
+
+			action.Into.IDLabels = &labels
+		}
+	case cleanupRelabel:
+		labels := append(append([]string{}, *action.Card.IDLabels...), tCli.GetConfig().Labels.Jira)
+
+		// todo: error returned from interface method should be wrapped
+		return tCli.UpdateCardLabels(ctx, action.Card.ID, strings.Join(labels, ","))
+	}
+
+	// todo: error returned from interface method should be wrapped
+	return tCli.ArchiveCard(ctx, action.Card.ID)
+}
+
+// mergeMembers returns comma separated members of both cards.
+func mergeMembers(a, b string) string {
+	res := make([]string, 0)
+	seen := map[string]bool{}
+
+	for _, id := range strings.Split(a+","+b, ",") {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			res = append(res, id)
+		}
+	}
+
+	return strings.Join(res, ",")
+}
+
+// mergeLabels returns labels of both cards.
+func mergeLabels(a, b *[]string) []string {
+	res := make([]string, 0)
+
+	for _, labels := range []*[]string{a, b} {
+		if labels == nil {
+			continue
+		}
+
+		for _, id := range *labels {
+			if !slices.Contains(res, id) {
+				res = append(res, id)
+			}
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"context"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_findCleanupActions(t *testing.T) {
+	const jiraLabel = "121212121212121212121fa4"
+
+	labeled := &[]string{jiraLabel}
+	cards := []*trello.Card{
+		{ID: "5f0000000000000000000002", Name: "K-1 | Task", Key: "K-1", IDLabels: labeled, IDMembers: "b"},
+		{ID: "5f0000000000000000000001", Name: "K-1 | Task", Key: "K-1", IDLabels: labeled, IDMembers: "a"},
+		{ID: "5f0000000000000000000003", Name: "K-2 | Task", Key: "K-2", IDLabels: labeled},
+		{ID: "5f0000000000000000000004", Name: "Renamed", IDLabels: labeled},
+		{ID: "5f0000000000000000000005", Name: "K-3 | Task", Key: "K-3", IDLabels: &[]string{}},
+		{ID: "5f0000000000000000000006", Name: "K-9 | Task", Key: "K-9", IDLabels: &[]string{}},
+		{ID: "5f0000000000000000000007", Name: "Shopping", IDLabels: &[]string{}},
+		{ID: "5f0000000000000000000008", Name: "EP-1 | Epic", Key: "EP-1", IDLabels: labeled,
+			Marker: &trello.Marker{Key: "EP-1", Type: trello.MarkerTypeEpic}},
+	}
+	existing := map[string]bool{"K-1": true, "K-3": true}
+
+	actions := findCleanupActions(cards, existing, jiraLabel)
+
+	got := make([][3]string, 0, len(actions))
+	for _, a := range actions {
+		into := ""
+		if a.Into != nil {
+			into = a.Into.ID
+		}
+
+		got = append(got, [3]string{a.Kind, a.Card.ID, into})
+	}
+
+	require.True(t, actions[0].Unverified)
+	require.True(t, actions[1].Unverified)
+	require.False(t, actions[2].Unverified)
+
+	require.Equal(t, [][3]string{
+		{cleanupArchive, "5f0000000000000000000003", ""},
+		{cleanupArchive, "5f0000000000000000000004", ""},
+		{cleanupRelabel, "5f0000000000000000000005", ""},
+		{cleanupArchiveDuplicate, "5f0000000000000000000002", "5f0000000000000000000001"},
+	}, got)
+
+	tCli := GetTrelloMockedCli(nil)
+	tCli.UpdateCardMembersFunc = func(ctx context.Context, cardID, members string) error {
+		return nil
+	}
+	tCli.ArchiveCardFunc = func(ctx context.Context, cardID string) error {
+		return nil
+	}
+
+	require.NoError(t, applyCleanupAction(context.Background(), tCli, actions[3]))
+	require.Equal(t, calls{{"5f0000000000000000000001", "a,b"}}, stringCalls(tCli.UpdateCardMembersCalls()))
+	require.Len(t, tCli.ArchiveCardCalls(), 1)
+	require.Equal(t, "5f0000000000000000000002", tCli.ArchiveCardCalls()[0].S)
+
+	require.NoError(t, applyCleanupAction(context.Background(), tCli, actions[2]))
+	require.Equal(t, calls{{"5f0000000000000000000005", jiraLabel}}, stringCalls(tCli.UpdateCardLabelsCalls()))
+	require.Len(t, tCli.ArchiveCardCalls(), 1)
+}
+
+func Test_findCleanupActions_richerCard(t *testing.T) {
+	const jiraLabel = "121212121212121212121fa4"
+
+	older := &trello.Card{ID: "5f0000000000000000000001", Key: "K-1", IDLabels: &[]string{jiraLabel}, IDMembers: "a"}
+	richer := &trello.Card{ID: "5f0000000000000000000002", Key: "K-1", IDLabels: &[]string{jiraLabel, "bug"},
+		IDMembers: "b", Details: 3, Desc: "Checklist and attachments"}
+	labeled := &trello.Card{ID: "5f0000000000000000000003", Key: "K-1", IDLabels: &[]string{jiraLabel, "feature"}}
+
+	actions := findCleanupActions([]*trello.Card{older, richer, labeled}, map[string]bool{"K-1": true}, jiraLabel)
+	require.Len(t, actions, 2)
+	require.Equal(t, richer, actions[0].Into)
+	require.Equal(t, labeled, actions[0].Card)
+	require.Equal(t, older, actions[1].Card)
+
+	older.Desc, older.Details = "Notes", 2
+	require.Equal(t, "archive duplicate `` (5f0000000000000000000001) and copy its members and labels to "+
+		"5f0000000000000000000002, its description and 2 checklist items, attachments and comments will be lost: "+
+		"duplicate card of K-1", actions[1].String())
+	require.Equal(t, "archive duplicate `` (5f0000000000000000000003) and copy its members and labels to "+
+		"5f0000000000000000000002: duplicate card of K-1", actions[0].String())
+
+	tCli := GetTrelloMockedCli(nil)
+	tCli.UpdateCardMembersFunc = func(ctx context.Context, cardID, members string) error {
+		return nil
+	}
+	tCli.ArchiveCardFunc = func(ctx context.Context, cardID string) error {
+		return nil
+	}
+
+	for _, action := range actions {
+		require.NoError(t, applyCleanupAction(context.Background(), tCli, action))
+	}
+
+	require.Equal(t, calls{{richer.ID, jiraLabel + ",bug,feature"}}, stringCalls(tCli.UpdateCardLabelsCalls()))
+	require.Equal(t, calls{{richer.ID, "b,a"}}, stringCalls(tCli.UpdateCardMembersCalls()))
+	require.Len(t, tCli.ArchiveCardCalls(), 2)
+}
+
+func Test_userCards(t *testing.T) {
+	cards := []*trello.Card{
+		{ID: "1", IDMembers: "user"},
+		{ID: "2", IDMembers: "other,user"},
+		{ID: "3", IDMembers: "other"},
+		{ID: "4", IDMembers: "user2"},
+		{ID: "5"},
+		{ID: "6", IDMembers: "user", Marker: &trello.Marker{Key: "K-1", Owner: "other"}},
+		{ID: "7", IDMembers: "other", Marker: &trello.Marker{Key: "K-2", Owner: "user"}},
+	}
+
+	ids := func(cards []*trello.Card) []string {
+		res := make([]string, 0, len(cards))
+		for _, card := range cards {
+			res = append(res, card.ID)
+		}
+
+		return res
+	}
+
+	require.Equal(t, []string{"1", "2", "6"}, ids(userCards(cards, &trello.Config{UserID: "user"})))
+	require.Equal(t, []string{"1", "2", "7"}, ids(userCards(cards, &trello.Config{UserID: "user", Shared: true})))
+}
@@ -0,0 +1,127 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// cardField is a card custom field filled from Jira task.
+type cardField struct {
+	name      string
+	fieldType string
+	value     func(task *jira.Task) string
+}
+
+var cardFields = []cardField{
+	{name: "Priority", fieldType: trello.CustomFieldList, value: func(task *jira.Task) string {
+		return task.Priority
+	}},
+	{name: "Story points", fieldType: trello.CustomFieldNumber, value: func(task *jira.Task) string {
+		if task.StoryPoints == 0 {
+			return ""
+		}
+
+		return strconv.FormatFloat(task.StoryPoints, 'f', -1, 64)
+	}},
+	{name: "Sprint", fieldType: trello.CustomFieldText, value: func(task *jira.Task) string {
+		return task.Sprint
+	}},
+	{name: "Fix versions", fieldType: trello.CustomFieldText, value: func(task *jira.Task) string {
+		return strings.Join(task.FixVersions, ", ")
+	}},
+	{name: "Components", fieldType: trello.CustomFieldText, value: func(task *jira.Task) string {
+		return strings.Join(task.Components, ", ")
+	}},
+	{name: "Jira labels", fieldType: trello.CustomFieldText, value: func(task *jira.Task) string {
+		return strings.Join(task.Labels, ", ")
+	}},
+}
+
+// prepareCustomFields creates missing board custom fields, when custom fields are enabled.
+func (s *SyncService) prepareCustomFields(ctx context.Context) error {
+	if !s.tCli.GetConfig().CustomFields {
+		return nil
+	}
+
+	fields, err := s.tCli.GetCustomFields(ctx)
+	if err != nil {
+		return fmt.Errorf("can't get custom fields: %w", err)
+	}
+
+	s.fields = map[string]*trello.CustomField{}
+
+	for _, cf := range cardFields {
+		field, ok := fields[cf.name]
+
+		switch {
+		case !ok:
+			slog.Info("Creating custom field", "field", cf.name)
+
+			if field, err = s.tCli.CreateCustomField(ctx, cf.name, cf.fieldType); err != nil {
+				return fmt.Errorf("can't create custom field `%s`: %w", cf.name, err)
+			}
+		case field.Type != cf.fieldType:
+			return fmt.Errorf("custom field `%s` has type `%s`, `%s` is expected", cf.name, field.Type, cf.fieldType)
+		}
+
+		s.fields[cf.name] = field
+	}
+
+	return nil
+}
+
+// updateCardFields sets card custom fields, which differ from Jira task.
+func (s *SyncService) updateCardFields(ctx context.Context, tCard *trello.Card, task *jira.Task) error {
+	if s.fields == nil {
+		return nil
+	}
+
+	for _, cf := range cardFields {
+		field := s.fields[cf.name]
+
+		value, err := s.customFieldValue(ctx, field, cf.value(task))
+		if err != nil {
+			return err
+		}
+
+		if tCard.CustomFields[field.ID] == value {
+			continue
+		}
+
+		slog.Info("Updating custom field", "key", task.Key, "field", cf.name)
+
+		if err := s.tCli.SetCardCustomField(ctx, tCard.ID, field, value); err != nil {
+			return fmt.Errorf("can't update custom field on card `%s`: %w", task.Key, err)
+		}
+	}
+
+	return nil
+}
+
+// customFieldValue returns option ID for list field, missing options are created.
+func (s *SyncService) customFieldValue(ctx context.Context, field *trello.CustomField, value string) (string, error) {
+	if field.Type != trello.CustomFieldList || value == "" {
+		return value, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := field.Options[value]; ok {
+		return id, nil
+	}
+
+	id, err := s.tCli.CreateCustomFieldOption(ctx, field.ID, value)
+	if err != nil {
+		return "", fmt.Errorf("can't add option to custom field `%s`: %w", field.Name, err)
+	}
+
+	field.Options[value] = id
+
+	return id, nil
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"context"
+	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSyncService_customFields(t *testing.T) {
+	tCli := GetTrelloMockedCli(nil)
+	cfg := tCli.GetConfig()
+	cfg.CustomFields = true
+	tCli.GetConfigFunc = func() *trello.Config {
+		return cfg
+	}
+	tCli.GetCustomFieldsFunc = func(ctx context.Context) (map[string]*trello.CustomField, error) {
+		return map[string]*trello.CustomField{
+			"Priority":     {ID: "f1", Name: "Priority", Type: "list", Options: map[string]string{"High": "o1"}},
+			"Story points": {ID: "f2", Name: "Story points", Type: "number", Options: map[string]string{}},
+			"Sprint":       {ID: "f3", Name: "Sprint", Type: "text", Options: map[string]string{}},
+		}, nil
+	}
+	tCli.CreateCustomFieldFunc = func(ctx context.Context, name, fieldType string) (*trello.CustomField, error) {
+		return &trello.CustomField{ID: "new " + name, Name: name, Type: fieldType, Options: map[string]string{}}, nil
+	}
+	tCli.CreateCustomFieldOptionFunc = func(ctx context.Context, fieldID, text string) (string, error) {
+		return "o2", nil
+	}
+	tCli.SetCardCustomFieldFunc = func(ctx context.Context, cardID string, field *trello.CustomField, value string) error {
+		return nil
+	}
+
+	s := &SyncService{tCli: tCli}
+	require.NoError(t, s.prepareCustomFields(context.Background()))
+	require.Len(t, tCli.CreateCustomFieldCalls(), 3)
+	require.Len(t, s.fields, len(cardFields))
+
+	card := &trello.Card{ID: "c1", CustomFields: map[string]string{"f1": "o1", "f2": "3", "f3": "Sprint 1"}}
+	task := &jira.Task{Key: "K-1", Priority: "High", StoryPoints: 3, Sprint: "Sprint 1"}
+
+	require.NoError(t, s.updateCardFields(context.Background(), card, task))
+	require.Empty(t, tCli.SetCardCustomFieldCalls())
+
+	task.Priority, task.StoryPoints, task.Sprint, task.Labels = "Low", 0.5, "", []string{"a", "b"}
+	require.NoError(t, s.updateCardFields(context.Background(), card, task))
+
+	got := map[string]string{}
+	for _, c := range tCli.SetCardCustomFieldCalls() {
+		got[c.CustomField.Name] = c.S2
+	}
+
+	require.Equal(t, map[string]string{
+		"Priority": "o2", "Story points": "0.5", "Sprint": "", "Jira labels": "a, b",
+	}, got)
+	require.Equal(t, calls{{"f1", "Low"}}, stringCalls(tCli.CreateCustomFieldOptionCalls()))
+}
+
+func TestSyncService_prepareCustomFields_wrongType(t *testing.T) {
+	tCli := GetTrelloMockedCli(nil)
+	cfg := tCli.GetConfig()
+	cfg.CustomFields = true
+	tCli.GetConfigFunc = func() *trello.Config {
+		return cfg
+	}
+	tCli.GetCustomFieldsFunc = func(ctx context.Context) (map[string]*trello.CustomField, error) {
+		return map[string]*trello.CustomField{
+			"Priority": {ID: "f1", Name: "Priority", Type: "text"},
+		}, nil
+	}
+
+	s := &SyncService{tCli: tCli}
+	require.Error(t, s.prepareCustomFields(context.Background()))
+}
@@ -0,0 +1,175 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+const (
+	daysInWeek     = 7
+	dateLayout     = "2006-01-02"
+	jqlDateLayout  = "2006-01-02 15:04"
+	fileDateLayout = "20060102"
+)
+
+var isoWeekRe = regexp.MustCompile(`^(\d{4})-W?(\d{1,2})$`)
+
+// DateRangeOptions describes report period from command line flags.
+type DateRangeOptions struct {
+	// Week in ISO format, e.g. 2026-W41.
+	Week string
+	// From and To are inclusive dates in 2006-01-02 format.
+	From       string
+	To         string
+	LastSprint bool
+}
+
+// isSet returns true if report period is set, otherwise report is built for the last 7 days.
+func (o DateRangeOptions) isSet() bool {
+	return o.Week != "" || o.From != "" || o.To != "" || o.LastSprint
+}
+
+// DateRange is a period [From, To) the report is built for.
+type DateRange struct {
+	From time.Time
+	To   time.Time
+	// Sprint is a name of the sprint the range is taken from.
+	Sprint string
+	// weekly range is named by ISO week instead of dates.
+	weekly bool
+}
+
+// defaultDateRange returns last 7 days including today.
+func defaultDateRange(now time.Time) DateRange {
+	today := startOfDay(now)
+
+	return DateRange{
+		From:   today.AddDate(0, 0, -daysInWeek),
+		To:     today.AddDate(0, 0, 1),
+		weekly: true,
+	}
+}
+
+func resolveDateRange(ctx context.Context, jCli JiraConnector, opts DateRangeOptions,
+	now time.Time) (DateRange, error) {
+	if !opts.LastSprint {
+		return parseDateRange(opts, now)
+	}
+
+	if err := jCli.Connect(ctx); err != nil {
+		return DateRange{}, fmt.Errorf("can't connect to jira server: %w", err)
+	}
+
+	sprint, err := jCli.GetLastSprint(ctx)
+	if err != nil {
+		return DateRange{}, fmt.Errorf("can't get last sprint: %w", err)
+	}
+
+	return DateRange{
+		From:   sprint.Start,
+		To:     sprint.End,
+		Sprint: sprint.Name,
+	}, nil
+}
+
+func parseDateRange(opts DateRangeOptions, now time.Time) (DateRange, error) {
+	if opts.Week != "" {
+		if opts.From != "" || opts.To != "" {
+			return DateRange{}, errors.New("week can't be combined with from/to dates")
+		}
+
+		return parseISOWeek(opts.Week, now.Location())
+	}
+
+	if opts.From == "" && opts.To == "" {
+		return defaultDateRange(now), nil
+	}
+
+	var (
+		res DateRange
+		err error
+	)
+
+	if opts.To != "" {
+		if res.To, err = time.ParseInLocation(dateLayout, opts.To, now.Location()); err != nil {
+			return DateRange{}, fmt.Errorf("invalid `to` date: %w", err)
+		}
+
+		res.To = res.To.AddDate(0, 0, 1)
+	} else {
+		res.To = startOfDay(now).AddDate(0, 0, 1)
+	}
+
+	if opts.From != "" {
+		if res.From, err = time.ParseInLocation(dateLayout, opts.From, now.Location()); err != nil {
+			return DateRange{}, fmt.Errorf("invalid `from` date: %w", err)
+		}
+	} else {
+		res.From = res.To.AddDate(0, 0, -daysInWeek)
+	}
+
+	if !res.From.Before(res.To) {
+		return DateRange{}, errors.New("`from` date should be before `to` date")
+	}
+
+	return res, nil
+}
+
+// parseISOWeek parses week in 2026-W41 or 2026-41 format.
+func parseISOWeek(s string, loc *time.Location) (DateRange, error) {
+	m := isoWeekRe.FindStringSubmatch(s)
+	if m == nil {
+		return DateRange{}, fmt.Errorf("invalid week `%s`, expected format is 2006-W01", s)
+	}
+
+	year, _ := strconv.Atoi(m[1])
+	week, _ := strconv.Atoi(m[2])
+
+	// January 4th is always in the first ISO week.
+	from := startOfISOWeek(time.Date(year, time.January, 4, 0, 0, 0, 0, loc)).AddDate(0, 0, (week-1)*daysInWeek)
+
+	if y, w := from.ISOWeek(); y != year || w != week {
+		return DateRange{}, fmt.Errorf("week %d doesn't exist in %d", week, year)
+	}
+
+	return DateRange{
+		From:   from,
+		To:     from.AddDate(0, 0, daysInWeek),
+		weekly: true,
+	}, nil
+}
+
+// ISOWeek returns ISO year and week of the last day in range.
+func (d DateRange) ISOWeek() (year, week int) {
+	return d.To.Add(-time.Nanosecond).ISOWeek()
+}
+
+func (d DateRange) fileSuffix() string {
+	if d.weekly {
+		year, week := d.ISOWeek()
+
+		return strconv.Itoa(year) + "-" + strconv.Itoa(week)
+	}
+
+	return d.From.Format(fileDateLayout) + "-" + d.To.Add(-time.Nanosecond).Format(fileDateLayout)
+}
+
+func (d DateRange) String() string {
+	return d.From.Format(dateLayout) + " - " + d.To.Add(-time.Nanosecond).Format(dateLayout)
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+func startOfISOWeek(t time.Time) time.Time {
+	weekday := (int(t.Weekday()) + daysInWeek - 1) % daysInWeek
+
+	return startOfDay(t).AddDate(0, 0, -weekday)
+}
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func Test_parseDateRange(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2026, month, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name       string
+		opts       DateRangeOptions
+		want       DateRange
+		wantSuffix string
+		wantErr    bool
+	}{
+		{
+			name:       "default",
+			opts:       DateRangeOptions{},
+			want:       DateRange{From: day(10, 7), To: day(10, 15), weekly: true},
+			wantSuffix: "2026-42",
+		},
+		{
+			name:       "week",
+			opts:       DateRangeOptions{Week: "2026-W41"},
+			want:       DateRange{From: day(10, 5), To: day(10, 12), weekly: true},
+			wantSuffix: "2026-41",
+		},
+		{
+			name:       "first week of the year starts in previous year",
+			opts:       DateRangeOptions{Week: "2026-1"},
+			want:       DateRange{From: time.Date(2025, 12, 29, 0, 0, 0, 0, time.UTC), To: day(1, 5), weekly: true},
+			wantSuffix: "2026-1",
+		},
+		{
+			name:       "from and to",
+			opts:       DateRangeOptions{From: "2026-10-01", To: "2026-10-14"},
+			want:       DateRange{From: day(10, 1), To: day(10, 15)},
+			wantSuffix: "20261001-20261014",
+		},
+		{
+			name:       "from only",
+			opts:       DateRangeOptions{From: "2026-10-01"},
+			want:       DateRange{From: day(10, 1), To: day(10, 15)},
+			wantSuffix: "20261001-20261014",
+		},
+		{
+			name:    "week doesn't exist",
+			opts:    DateRangeOptions{Week: "2025-W53"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid week",
+			opts:    DateRangeOptions{Week: "W41"},
+			wantErr: true,
+		},
+		{
+			name:    "from after to",
+			opts:    DateRangeOptions{From: "2026-10-14", To: "2026-10-01"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDateRange(tt.opts, now)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantSuffix, got.fileSuffix())
+		})
+	}
+}
+
+func TestDateRangeOptions_isSet(t *testing.T) {
+	require.False(t, DateRangeOptions{}.isSet())
+	require.True(t, DateRangeOptions{Week: "2026-W41"}.isSet())
+	require.True(t, DateRangeOptions{To: "2026-10-14"}.isSet())
+	require.True(t, DateRangeOptions{LastSprint: true}.isSet())
+}
@@ -0,0 +1,163 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Brialius/jira2trello/internal/theme"
+	"github.com/mattn/go-colorable"
+	"io"
+	"log"
+	"strings"
+	"time"
+)
+
+var errSkipped = errors.New("skipped")
+
+type doctorCheck struct {
+	Name string
+	Err  error
+}
+
+// Doctor checks connection to Jira and Trello, JQL templates and board configuration.
+func Doctor(ctx context.Context, jCli JiraConnector, tCli TrelloConnector, jql JQLConfig, th *theme.Theme) {
+	checks := runDoctorChecks(ctx, jCli, tCli, jql, time.Now())
+
+	if !printDoctorChecks(colorable.NewColorableStdout(), th, checks) {
+		log.Fatalf("Some checks failed")
+	}
+}
+
+func runDoctorChecks(ctx context.Context, jCli JiraConnector, tCli TrelloConnector, jql JQLConfig,
+	now time.Time) []doctorCheck {
+	checks := make([]doctorCheck, 0)
+
+	jiraErr := jCli.Connect(ctx)
+	checks = append(checks, doctorCheck{Name: "Jira connection", Err: jiraErr})
+
+	validate := func(query string, err error) error {
+		switch {
+		case err != nil:
+			return err
+		case jiraErr != nil:
+			return errSkipped
+		}
+
+		if err := jCli.ValidateJQL(ctx, query); err != nil {
+			return fmt.Errorf("%w, query: %s", err, query)
+		}
+
+		return nil
+	}
+
+	checks = append(checks,
+		doctorCheck{Name: "Sync JQL", Err: validate(jql.syncJQL(now))},
+		doctorCheck{Name: "Weekly JQL", Err: validate(jql.weeklyJQL(defaultDateRange(now), ""))},
+	)
+
+	trelloErr := tCli.Connect(ctx)
+	checks = append(checks, doctorCheck{Name: "Trello connection", Err: trelloErr})
+
+	if trelloErr != nil {
+		return append(checks,
+			doctorCheck{Name: "Trello lists", Err: errSkipped},
+			doctorCheck{Name: "Trello labels", Err: errSkipped},
+		)
+	}
+
+	return append(checks,
+		doctorCheck{Name: "Trello lists", Err: checkTrelloLists(ctx, tCli)},
+		doctorCheck{Name: "Trello labels", Err: checkTrelloLabels(ctx, tCli)},
+	)
+}
+
+func checkTrelloLists(ctx context.Context, tCli TrelloConnector) error {
+	lists, err := tCli.GetLists(ctx)
+	if err != nil {
+		return err
+	}
+
+	ids := map[string]bool{}
+	for _, list := range lists {
+		ids[list.ID] = true
+	}
+
+	cfg := tCli.GetConfig().Lists
+	if cfg == nil {
+		return errors.New("lists are not configured")
+	}
+
+	return checkConfiguredIDs(ids, []configuredID{
+		{"todo", cfg.Todo},
+		{"doing", cfg.Doing},
+		{"done", cfg.Done},
+		{"review", cfg.Review},
+		{"bucket", cfg.Bucket},
+	})
+}
+
+func checkTrelloLabels(ctx context.Context, tCli TrelloConnector) error {
+	labels, err := tCli.GetLabels(ctx)
+	if err != nil {
+		return err
+	}
+
+	ids := map[string]bool{}
+	for _, label := range labels {
+		ids[label.ID] = true
+	}
+
+	cfg := tCli.GetConfig().Labels
+	if cfg == nil {
+		return errors.New("labels are not configured")
+	}
+
+	return checkConfiguredIDs(ids, []configuredID{
+		{"jira", cfg.Jira},
+		{"blocked", cfg.Blocked},
+		{"task", cfg.Task},
+		{"bug", cfg.Bug},
+		{"story", cfg.Story},
+	})
+}
+
+type configuredID struct {
+	name string
+	id   string
+}
+
+// checkConfiguredIDs returns error listing config keys, which IDs are missing on the board.
+func checkConfiguredIDs(existing map[string]bool, configured []configuredID) error {
+	missing := make([]string, 0)
+
+	for _, c := range configured {
+		if !existing[c.id] {
+			missing = append(missing, c.name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("not found on the board: %s, run `configure` to fix", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// printDoctorChecks prints checks result and returns false if any check failed.
+func printDoctorChecks(out io.Writer, th *theme.Theme, checks []doctorCheck) bool {
+	res := true
+
+	for _, check := range checks {
+		switch {
+		case check.Err == nil:
+			_, _ = fmt.Fprintf(out, "%s %s\n", th.Paint(theme.Green, "[ OK ]"), check.Name)
+		case errors.Is(check.Err, errSkipped):
+			_, _ = fmt.Fprintf(out, "%s %s\n", th.Paint(theme.Yellow, "[SKIP]"), check.Name)
+		default:
+			res = false
+			_, _ = fmt.Fprintf(out, "%s %s: %s\n", th.Paint(theme.Red, "[FAIL]"), check.Name, check.Err)
+		}
+	}
+
+	return res
+}
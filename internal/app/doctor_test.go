@@ -0,0 +1,45 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"github.com/mattn/go-colorable"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_runDoctorChecks(t *testing.T) {
+	jCli := GetJiraMockedCli(nil)
+	jCli.ValidateJQLFunc = func(ctx context.Context, jql string) error {
+		if strings.Contains(jql, "reviewer") {
+			return errors.New("field 'reviewer' does not exist")
+		}
+
+		return nil
+	}
+
+	tCli := GetTrelloMockedCli(nil)
+	lists := tCli.GetListsFunc
+	tCli.GetListsFunc = func(ctx context.Context) (map[string]*trello.List, error) {
+		res, err := lists(ctx)
+		delete(res, "Bucket")
+
+		return res, err
+	}
+
+	checks := runDoctorChecks(context.Background(), jCli, tCli, JQLConfig{Sync: "reviewer = {{ .User }}"}, time.Now())
+
+	out := &bytes.Buffer{}
+	require.False(t, printDoctorChecks(colorable.NewNonColorable(out), nil, checks))
+	require.Equal(t, `[ OK ] Jira connection
+[FAIL] Sync JQL: field 'reviewer' does not exist, query: reviewer = currentUser()
+[ OK ] Weekly JQL
+[ OK ] Trello connection
+[FAIL] Trello lists: not found on the board: bucket, run `+"`configure`"+` to fix
+[ OK ] Trello labels
+`, out.String())
+}
@@ -0,0 +1,267 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"hash/fnv"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var epicLabelColors = []string{"green", "yellow", "orange", "red", "purple", "blue", "sky", "lime", "pink", "black"}
+
+func (s *SyncService) epicsMode() string {
+	if epics := s.tCli.GetConfig().Epics; epics != nil {
+		return epics.Mode
+	}
+
+	return ""
+}
+
+// prepareEpics resolves epics of Jira tasks and loads epic labels or cards, when epic grouping is enabled.
+func (s *SyncService) prepareEpics(ctx context.Context) error {
+	mode := s.epicsMode()
+	if mode == "" {
+		return nil
+	}
+
+	if err := s.jCli.ResolveEpics(ctx, s.jTasks); err != nil {
+		return fmt.Errorf("can't resolve epics: %w", err)
+	}
+
+	switch mode {
+	case trello.EpicsModeLabel:
+		labels, err := s.tCli.GetLabels(ctx)
+		if err != nil {
+			return fmt.Errorf("can't get labels: %w", err)
+		}
+
+		s.epicLabels = map[string]string{}
+		s.newEpicLabels = map[string]*epicLabelCreation{}
+
+		for name, label := range labels {
+			s.epicLabels[name] = label.ID
+		}
+	case trello.EpicsModeCard:
+		cards, err := s.tCli.GetEpicCards(ctx)
+		if err != nil {
+			return fmt.Errorf("can't get epic cards: %w", err)
+		}
+
+		s.epicCards = map[string]*trello.Card{}
+		for _, card := range cards {
+			s.epicCards[card.Key] = card
+		}
+	default:
+		return fmt.Errorf("unknown epics mode `%s`", mode)
+	}
+
+	return nil
+}
+
+// epicLabel returns ID of the task epic label, label is created if it doesn't exist.
+func (s *SyncService) epicLabel(ctx context.Context, task *jira.Task) (string, error) {
+	if s.epicLabels == nil || task.EpicKey == "" {
+		return "", nil
+	}
+
+	name := epicName(task)
+
+	s.mu.Lock()
+
+	if id, ok := s.epicLabels[name]; ok {
+		s.mu.Unlock()
+
+		return id, nil
+	}
+
+	creation, ok := s.newEpicLabels[name]
+	if !ok {
+		creation = &epicLabelCreation{}
+		s.newEpicLabels[name] = creation
+	}
+
+	s.mu.Unlock()
+
+	// Label is created once, other workers wait for it without blocking lookups of other labels.
+	creation.once.Do(func() {
+		slog.Info("Creating epic label", "epic", task.EpicKey)
+
+		label, err := s.tCli.CreateLabel(ctx, name, epicLabelColor(task.EpicKey))
+		if err != nil {
+			creation.err = fmt.Errorf("can't create label for epic `%s`: %w", task.EpicKey, err)
+
+			return
+		}
+
+		creation.id = label.ID
+
+		s.mu.Lock()
+		s.epicLabels[name] = label.ID
+		s.mu.Unlock()
+	})
+
+	return creation.id, creation.err
+}
+
+// epicLabelCreation is epic label created by one of sync workers.
+type epicLabelCreation struct {
+	once sync.Once
+	id   string
+	err  error
+}
+
+func epicName(task *jira.Task) string {
+	if task.EpicName != "" {
+		return task.EpicName
+	}
+
+	return task.EpicKey
+}
+
+// epicLabelColor returns the same color for the epic on each run.
+func epicLabelColor(key string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+
+	return epicLabelColors[h.Sum32()%uint32(len(epicLabelColors))]
+}
+
+// syncEpicCards creates epic cards and keeps their checklists up to date. Tasks synced by the user are
+// unchecked, tasks completed by the user are checked, other items are left as is.
+func (s *SyncService) syncEpicCards(ctx context.Context) error {
+	if s.epicCards == nil {
+		return nil
+	}
+
+	epics := map[string][]*jira.Task{}
+
+	for _, task := range s.jTasks {
+		if task.EpicKey != "" {
+			epics[task.EpicKey] = append(epics[task.EpicKey], task)
+		}
+	}
+
+	for _, key := range sortedKeys(epics) {
+		if err := s.syncEpicCard(ctx, epics[key]); err != nil {
+			return err
+		}
+	}
+
+	for _, card := range s.epicCards {
+		for _, item := range card.CheckItems {
+			key := checkItemKey(item.Name)
+			if _, ok := s.jTasks[key]; ok || item.Complete {
+				continue
+			}
+
+			if _, ok := s.tCards[key]; !ok {
+				continue
+			}
+
+			slog.Info("Checking task in epic", "key", key, "epic", card.Key)
+
+			if err := s.tCli.SetCheckItemState(ctx, card.ID, item.ID, true); err != nil {
+				return fmt.Errorf("can't check item `%s` on epic card `%s`: %w", key, card.Key, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *SyncService) syncEpicCard(ctx context.Context, tasks []*jira.Task) error {
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].Key < tasks[j].Key
+	})
+
+	epic := tasks[0]
+
+	card, ok := s.epicCards[epic.EpicKey]
+	if !ok {
+		var err error
+		if card, err = s.addEpicCard(ctx, epic); err != nil {
+			return fmt.Errorf("can't add epic card `%s`: %w", epic.EpicKey, err)
+		}
+	}
+
+	items := map[string]*trello.CheckItem{}
+	for _, item := range card.CheckItems {
+		items[checkItemKey(item.Name)] = item
+	}
+
+	for _, task := range tasks {
+		item, ok := items[task.Key]
+
+		switch {
+		case !ok:
+			slog.Info("Adding task to epic", "key", task.Key, "epic", epic.EpicKey)
+
+			if err := s.tCli.AddCheckItem(ctx, card, task.Key+" | "+task.Summary); err != nil {
+				return fmt.Errorf("can't add item `%s` to epic card `%s`: %w", task.Key, epic.EpicKey, err)
+			}
+		case item.Complete:
+			if err := s.tCli.SetCheckItemState(ctx, card.ID, item.ID, false); err != nil {
+				return fmt.Errorf("can't uncheck item `%s` on epic card `%s`: %w", task.Key, epic.EpicKey, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *SyncService) addEpicCard(ctx context.Context, task *jira.Task) (*trello.Card, error) {
+	cfg := s.tCli.GetConfig()
+
+	listID := cfg.Epics.List
+	if listID == "" {
+		listID = cfg.Lists.Todo
+	}
+
+	slog.Info("Adding epic card", "epic", task.EpicKey, "list", trello.GetListNameByID(listID, cfg.Lists))
+
+	card := &trello.Card{
+		Name:      task.EpicKey + " | " + epicName(task),
+		ListID:    listID,
+		Desc:      "Jira link: " + task.EpicLink,
+		IDLabels:  &[]string{cfg.Labels.Jira},
+		IDMembers: cfg.UserID,
+		Key:       task.EpicKey,
+		Marker: &trello.Marker{
+			Key:   task.EpicKey,
+			Link:  task.EpicLink,
+			Owner: cfg.UserID,
+			Type:  trello.MarkerTypeEpic,
+		},
+	}
+
+	if err := s.tCli.CreateCard(ctx, card); err != nil {
+		// todo: error returned from interface method should be wrapped
+		return nil, err
+	}
+
+	s.epicCards[task.EpicKey] = card
+
+	return card, nil
+}
+
+// checkItemKey returns Jira key of checklist item named as `KEY | Summary`.
+func checkItemKey(name string) string {
+	return strings.TrimSpace(strings.Split(name, "|")[0])
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	res := make([]string, 0, len(m))
+
+	for key := range m {
+		res = append(res, key)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
@@ -0,0 +1,169 @@
+package app
+
+import (
+	"context"
+	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func getEpicsMockedCli(mode string) *TrelloConnectorMock {
+	tCli := GetTrelloMockedCli(nil)
+	cfg := tCli.GetConfig()
+	cfg.Epics = &trello.Epics{Mode: mode}
+	tCli.GetConfigFunc = func() *trello.Config {
+		return cfg
+	}
+
+	return tCli
+}
+
+func TestSyncService_epicLabel(t *testing.T) {
+	tCli := getEpicsMockedCli(trello.EpicsModeLabel)
+	tCli.CreateLabelFunc = func(ctx context.Context, name, color string) (*trello.Label, error) {
+		return &trello.Label{Name: name, ID: "new"}, nil
+	}
+
+	jCli := GetJiraMockedCli(nil)
+	jCli.ResolveEpicsFunc = func(ctx context.Context, tasks map[string]*jira.Task) error {
+		return nil
+	}
+
+	s := &SyncService{jCli: jCli, tCli: tCli}
+	require.NoError(t, s.prepareEpics(context.Background()))
+	require.Len(t, jCli.ResolveEpicsCalls(), 1)
+
+	id, err := s.epicLabel(context.Background(), &jira.Task{EpicKey: "EP-1", EpicName: "Story"})
+	require.NoError(t, err)
+	require.Equal(t, "12121212121212121212a0c8", id)
+
+	id, err = s.epicLabel(context.Background(), &jira.Task{EpicKey: "EP-2", EpicName: "New epic"})
+	require.NoError(t, err)
+	require.Equal(t, "new", id)
+
+	id, err = s.epicLabel(context.Background(), &jira.Task{EpicKey: "EP-2", EpicName: "New epic"})
+	require.NoError(t, err)
+	require.Equal(t, "new", id)
+
+	id, err = s.epicLabel(context.Background(), &jira.Task{})
+	require.NoError(t, err)
+	require.Empty(t, id)
+
+	require.Equal(t, calls{{"New epic", epicLabelColor("EP-2")}}, stringCalls(tCli.CreateLabelCalls()))
+}
+
+func TestSyncService_syncEpicCards(t *testing.T) {
+	tCli := getEpicsMockedCli(trello.EpicsModeCard)
+	tCli.GetEpicCardsFunc = func(ctx context.Context) ([]*trello.Card, error) {
+		return []*trello.Card{{
+			ID: "e1", Key: "EP-1", CheckItems: []*trello.CheckItem{
+				{ID: "i1", Name: "K-1 | Task 1", Complete: true},
+				{ID: "i2", Name: "K-2 | Task 2"},
+				{ID: "i3", Name: "K-3 | Task 3"},
+			},
+		}}, nil
+	}
+	tCli.CreateCardFunc = func(ctx context.Context, card *trello.Card) error {
+		card.ID = "e2"
+
+		return nil
+	}
+	tCli.AddCheckItemFunc = func(ctx context.Context, card *trello.Card, name string) error {
+		return nil
+	}
+	tCli.SetCheckItemStateFunc = func(ctx context.Context, cardID, itemID string, complete bool) error {
+		return nil
+	}
+
+	jCli := GetJiraMockedCli(nil)
+	jCli.ResolveEpicsFunc = func(ctx context.Context, tasks map[string]*jira.Task) error {
+		return nil
+	}
+
+	s := &SyncService{
+		jCli: jCli,
+		tCli: tCli,
+		jTasks: map[string]*jira.Task{
+			"K-1": {Key: "K-1", Summary: "Task 1", EpicKey: "EP-1"},
+			"K-4": {Key: "K-4", Summary: "Task 4", EpicKey: "EP-1"},
+			"K-5": {
+				Key: "K-5", Summary: "Task 5", EpicKey: "EP-2", EpicName: "Epic 2",
+				EpicLink: "https://jira-site/browse/EP-2",
+			},
+			"K-6": {Key: "K-6", Summary: "Task 6"},
+		},
+		// K-2 is completed by current user, K-3 isn't synced by current user.
+		tCards: map[string]*trello.Card{"K-2": {Key: "K-2"}},
+	}
+
+	require.NoError(t, s.prepareEpics(context.Background()))
+	require.NoError(t, s.syncEpicCards(context.Background()))
+
+	created := tCli.CreateCardCalls()
+	require.Len(t, created, 1)
+	require.Equal(t, "EP-2 | Epic 2", created[0].Card.Name)
+	require.Equal(t, &trello.Marker{
+		Key: "EP-2", Link: "https://jira-site/browse/EP-2", Owner: "111111111111111111111111", Type: "epic",
+	}, created[0].Card.Marker)
+
+	added := map[string]string{}
+	for _, c := range tCli.AddCheckItemCalls() {
+		added[c.S] = c.Card.ID
+	}
+
+	require.Equal(t, map[string]string{"K-4 | Task 4": "e1", "K-5 | Task 5": "e2"}, added)
+
+	states := map[string]bool{}
+	for _, c := range tCli.SetCheckItemStateCalls() {
+		states[c.S1+"/"+c.S2] = c.B
+	}
+
+	require.Equal(t, map[string]bool{"e1/i1": false, "e1/i2": true}, states)
+}
+
+func TestSyncService_epicLabel_parallel(t *testing.T) {
+	created := make(chan struct{})
+	release := make(chan struct{})
+
+	tCli := getEpicsMockedCli(trello.EpicsModeLabel)
+	tCli.CreateLabelFunc = func(ctx context.Context, name, color string) (*trello.Label, error) {
+		close(created)
+		<-release
+
+		return &trello.Label{Name: name, ID: "new"}, nil
+	}
+
+	jCli := GetJiraMockedCli(nil)
+	jCli.ResolveEpicsFunc = func(ctx context.Context, tasks map[string]*jira.Task) error {
+		return nil
+	}
+
+	s := &SyncService{jCli: jCli, tCli: tCli}
+	require.NoError(t, s.prepareEpics(context.Background()))
+
+	ids := make(chan string, 3)
+
+	for i := 0; i < 3; i++ {
+		go func() {
+			id, err := s.epicLabel(context.Background(), &jira.Task{EpicKey: "EP-2", EpicName: "New epic"})
+			require.NoError(t, err)
+			ids <- id
+		}()
+	}
+
+	<-created
+
+	// Existing labels are resolved while the new one is being created.
+	id, err := s.epicLabel(context.Background(), &jira.Task{EpicKey: "EP-1", EpicName: "Story"})
+	require.NoError(t, err)
+	require.Equal(t, "12121212121212121212a0c8", id)
+
+	close(release)
+
+	for i := 0; i < 3; i++ {
+		require.Equal(t, "new", <-ids)
+	}
+
+	require.Len(t, tCli.CreateLabelCalls(), 1)
+}
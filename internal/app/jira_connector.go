@@ -21,11 +21,26 @@ THE SOFTWARE.
 */
 package app
 
-import "github.com/Brialius/jira2trello/internal/jira"
+import (
+	"context"
+	"github.com/Brialius/jira2trello/internal/jira"
+	"time"
+)
 
 //go:generate moq -out jira_connector_moq_test.go . JiraConnector
 
 type JiraConnector interface {
-	Connect() error
-	GetUserTasks(jql string) (map[string]*jira.Task, error)
+	Connect(ctx context.Context) error
+	GetTasks(ctx context.Context, jql string) (map[string]*jira.Task, error)
+	GetWatchers(ctx context.Context, key string) ([]*jira.User, error)
+	ValidateJQL(ctx context.Context, jql string) error
+	GetLastSprint(ctx context.Context) (*jira.Sprint, error)
+	ResolveEpics(ctx context.Context, tasks map[string]*jira.Task) error
+	GetExistingKeys(ctx context.Context, keys []string) (map[string]bool, error)
+	CreateTask(ctx context.Context, project, issueType, summary, desc string) (*jira.Task, error)
+	GetTransitions(ctx context.Context, key string) ([]*jira.Transition, error)
+	DoTransition(ctx context.Context, key, transitionID string) error
+	AddWorklog(ctx context.Context, key string, spent time.Duration) error
+	RegisterWebhook(ctx context.Context, name, callbackURL, jql string) error
+	GetSelf(ctx context.Context) (*jira.User, error)
 }
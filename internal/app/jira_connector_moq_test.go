@@ -4,8 +4,10 @@
 package app
 
 import (
+	"context"
 	"github.com/Brialius/jira2trello/internal/jira"
 	"sync"
+	"time"
 )
 
 // Ensure, that JiraConnectorMock does implement JiraConnector.
@@ -14,63 +16,277 @@ var _ JiraConnector = &JiraConnectorMock{}
 
 // JiraConnectorMock is a mock implementation of JiraConnector.
 //
-// 	func TestSomethingThatUsesJiraConnector(t *testing.T) {
+//	func TestSomethingThatUsesJiraConnector(t *testing.T) {
 //
-// 		// make and configure a mocked JiraConnector
-// 		mockedJiraConnector := &JiraConnectorMock{
-// 			ConnectFunc: func() error {
-// 				panic("mock out the Connect method")
-// 			},
-// 			GetUserTasksFunc: func(jql string) (map[string]*jira.Task, error) {
-// 				panic("mock out the GetUserTasks method")
-// 			},
-// 		}
+//		// make and configure a mocked JiraConnector
+//		mockedJiraConnector := &JiraConnectorMock{
+//			AddWorklogFunc: func(ctx context.Context, key string, spent time.Duration) error {
+//				panic("mock out the AddWorklog method")
+//			},
+//			ConnectFunc: func(ctx context.Context) error {
+//				panic("mock out the Connect method")
+//			},
+//			CreateTaskFunc: func(ctx context.Context, project string, issueType string, summary string, desc string) (*jira.Task, error) {
+//				panic("mock out the CreateTask method")
+//			},
+//			DoTransitionFunc: func(ctx context.Context, key string, transitionID string) error {
+//				panic("mock out the DoTransition method")
+//			},
+//			GetExistingKeysFunc: func(ctx context.Context, keys []string) (map[string]bool, error) {
+//				panic("mock out the GetExistingKeys method")
+//			},
+//			GetLastSprintFunc: func(ctx context.Context) (*jira.Sprint, error) {
+//				panic("mock out the GetLastSprint method")
+//			},
+//			GetSelfFunc: func(ctx context.Context) (*jira.User, error) {
+//				panic("mock out the GetSelf method")
+//			},
+//			GetTasksFunc: func(ctx context.Context, jql string) (map[string]*jira.Task, error) {
+//				panic("mock out the GetTasks method")
+//			},
+//			GetTransitionsFunc: func(ctx context.Context, key string) ([]*jira.Transition, error) {
+//				panic("mock out the GetTransitions method")
+//			},
+//			GetWatchersFunc: func(ctx context.Context, key string) ([]*jira.User, error) {
+//				panic("mock out the GetWatchers method")
+//			},
+//			RegisterWebhookFunc: func(ctx context.Context, name string, callbackURL string, jql string) error {
+//				panic("mock out the RegisterWebhook method")
+//			},
+//			ResolveEpicsFunc: func(ctx context.Context, tasks map[string]*jira.Task) error {
+//				panic("mock out the ResolveEpics method")
+//			},
+//			ValidateJQLFunc: func(ctx context.Context, jql string) error {
+//				panic("mock out the ValidateJQL method")
+//			},
+//		}
 //
-// 		// use mockedJiraConnector in code that requires JiraConnector
-// 		// and then make assertions.
+//		// use mockedJiraConnector in code that requires JiraConnector
+//		// and then make assertions.
 //
-// 	}
+//	}
 type JiraConnectorMock struct {
+	// AddWorklogFunc mocks the AddWorklog method.
+	AddWorklogFunc func(ctx context.Context, key string, spent time.Duration) error
+
 	// ConnectFunc mocks the Connect method.
-	ConnectFunc func() error
+	ConnectFunc func(ctx context.Context) error
+
+	// CreateTaskFunc mocks the CreateTask method.
+	CreateTaskFunc func(ctx context.Context, project string, issueType string, summary string, desc string) (*jira.Task, error)
+
+	// DoTransitionFunc mocks the DoTransition method.
+	DoTransitionFunc func(ctx context.Context, key string, transitionID string) error
+
+	// GetExistingKeysFunc mocks the GetExistingKeys method.
+	GetExistingKeysFunc func(ctx context.Context, keys []string) (map[string]bool, error)
+
+	// GetLastSprintFunc mocks the GetLastSprint method.
+	GetLastSprintFunc func(ctx context.Context) (*jira.Sprint, error)
+
+	// GetSelfFunc mocks the GetSelf method.
+	GetSelfFunc func(ctx context.Context) (*jira.User, error)
 
-	// GetUserTasksFunc mocks the GetUserTasks method.
-	GetUserTasksFunc func(jql string) (map[string]*jira.Task, error)
+	// GetTasksFunc mocks the GetTasks method.
+	GetTasksFunc func(ctx context.Context, jql string) (map[string]*jira.Task, error)
+
+	// GetTransitionsFunc mocks the GetTransitions method.
+	GetTransitionsFunc func(ctx context.Context, key string) ([]*jira.Transition, error)
+
+	// GetWatchersFunc mocks the GetWatchers method.
+	GetWatchersFunc func(ctx context.Context, key string) ([]*jira.User, error)
+
+	// RegisterWebhookFunc mocks the RegisterWebhook method.
+	RegisterWebhookFunc func(ctx context.Context, name string, callbackURL string, jql string) error
+
+	// ResolveEpicsFunc mocks the ResolveEpics method.
+	ResolveEpicsFunc func(ctx context.Context, tasks map[string]*jira.Task) error
+
+	// ValidateJQLFunc mocks the ValidateJQL method.
+	ValidateJQLFunc func(ctx context.Context, jql string) error
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// AddWorklog holds details about calls to the AddWorklog method.
+		AddWorklog []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Spent is the spent argument value.
+			Spent time.Duration
+		}
 		// Connect holds details about calls to the Connect method.
 		Connect []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 		}
-		// GetUserTasks holds details about calls to the GetUserTasks method.
-		GetUserTasks []struct {
+		// CreateTask holds details about calls to the CreateTask method.
+		CreateTask []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Project is the project argument value.
+			Project string
+			// IssueType is the issueType argument value.
+			IssueType string
+			// Summary is the summary argument value.
+			Summary string
+			// Desc is the desc argument value.
+			Desc string
+		}
+		// DoTransition holds details about calls to the DoTransition method.
+		DoTransition []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// TransitionID is the transitionID argument value.
+			TransitionID string
+		}
+		// GetExistingKeys holds details about calls to the GetExistingKeys method.
+		GetExistingKeys []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Keys is the keys argument value.
+			Keys []string
+		}
+		// GetLastSprint holds details about calls to the GetLastSprint method.
+		GetLastSprint []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetSelf holds details about calls to the GetSelf method.
+		GetSelf []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetTasks holds details about calls to the GetTasks method.
+		GetTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Jql is the jql argument value.
+			Jql string
+		}
+		// GetTransitions holds details about calls to the GetTransitions method.
+		GetTransitions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// GetWatchers holds details about calls to the GetWatchers method.
+		GetWatchers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// RegisterWebhook holds details about calls to the RegisterWebhook method.
+		RegisterWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// CallbackURL is the callbackURL argument value.
+			CallbackURL string
 			// Jql is the jql argument value.
 			Jql string
 		}
+		// ResolveEpics holds details about calls to the ResolveEpics method.
+		ResolveEpics []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Tasks is the tasks argument value.
+			Tasks map[string]*jira.Task
+		}
+		// ValidateJQL holds details about calls to the ValidateJQL method.
+		ValidateJQL []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Jql is the jql argument value.
+			Jql string
+		}
+	}
+	lockAddWorklog      sync.RWMutex
+	lockConnect         sync.RWMutex
+	lockCreateTask      sync.RWMutex
+	lockDoTransition    sync.RWMutex
+	lockGetExistingKeys sync.RWMutex
+	lockGetLastSprint   sync.RWMutex
+	lockGetSelf         sync.RWMutex
+	lockGetTasks        sync.RWMutex
+	lockGetTransitions  sync.RWMutex
+	lockGetWatchers     sync.RWMutex
+	lockRegisterWebhook sync.RWMutex
+	lockResolveEpics    sync.RWMutex
+	lockValidateJQL     sync.RWMutex
+}
+
+// AddWorklog calls AddWorklogFunc.
+func (mock *JiraConnectorMock) AddWorklog(ctx context.Context, key string, spent time.Duration) error {
+	if mock.AddWorklogFunc == nil {
+		panic("JiraConnectorMock.AddWorklogFunc: method is nil but JiraConnector.AddWorklog was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Key   string
+		Spent time.Duration
+	}{
+		Ctx:   ctx,
+		Key:   key,
+		Spent: spent,
 	}
-	lockConnect      sync.RWMutex
-	lockGetUserTasks sync.RWMutex
+	mock.lockAddWorklog.Lock()
+	mock.calls.AddWorklog = append(mock.calls.AddWorklog, callInfo)
+	mock.lockAddWorklog.Unlock()
+	return mock.AddWorklogFunc(ctx, key, spent)
+}
+
+// AddWorklogCalls gets all the calls that were made to AddWorklog.
+// Check the length with:
+//
+//	len(mockedJiraConnector.AddWorklogCalls())
+func (mock *JiraConnectorMock) AddWorklogCalls() []struct {
+	Ctx   context.Context
+	Key   string
+	Spent time.Duration
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Key   string
+		Spent time.Duration
+	}
+	mock.lockAddWorklog.RLock()
+	calls = mock.calls.AddWorklog
+	mock.lockAddWorklog.RUnlock()
+	return calls
 }
 
 // Connect calls ConnectFunc.
-func (mock *JiraConnectorMock) Connect() error {
+func (mock *JiraConnectorMock) Connect(ctx context.Context) error {
 	if mock.ConnectFunc == nil {
 		panic("JiraConnectorMock.ConnectFunc: method is nil but JiraConnector.Connect was just called")
 	}
 	callInfo := struct {
-	}{}
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
 	mock.lockConnect.Lock()
 	mock.calls.Connect = append(mock.calls.Connect, callInfo)
 	mock.lockConnect.Unlock()
-	return mock.ConnectFunc()
+	return mock.ConnectFunc(ctx)
 }
 
 // ConnectCalls gets all the calls that were made to Connect.
 // Check the length with:
-//     len(mockedJiraConnector.ConnectCalls())
+//
+//	len(mockedJiraConnector.ConnectCalls())
 func (mock *JiraConnectorMock) ConnectCalls() []struct {
+	Ctx context.Context
 } {
 	var calls []struct {
+		Ctx context.Context
 	}
 	mock.lockConnect.RLock()
 	calls = mock.calls.Connect
@@ -78,33 +294,414 @@ func (mock *JiraConnectorMock) ConnectCalls() []struct {
 	return calls
 }
 
-// GetUserTasks calls GetUserTasksFunc.
-func (mock *JiraConnectorMock) GetUserTasks(jql string) (map[string]*jira.Task, error) {
-	if mock.GetUserTasksFunc == nil {
-		panic("JiraConnectorMock.GetUserTasksFunc: method is nil but JiraConnector.GetUserTasks was just called")
+// CreateTask calls CreateTaskFunc.
+func (mock *JiraConnectorMock) CreateTask(ctx context.Context, project string, issueType string, summary string, desc string) (*jira.Task, error) {
+	if mock.CreateTaskFunc == nil {
+		panic("JiraConnectorMock.CreateTaskFunc: method is nil but JiraConnector.CreateTask was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Project   string
+		IssueType string
+		Summary   string
+		Desc      string
+	}{
+		Ctx:       ctx,
+		Project:   project,
+		IssueType: issueType,
+		Summary:   summary,
+		Desc:      desc,
+	}
+	mock.lockCreateTask.Lock()
+	mock.calls.CreateTask = append(mock.calls.CreateTask, callInfo)
+	mock.lockCreateTask.Unlock()
+	return mock.CreateTaskFunc(ctx, project, issueType, summary, desc)
+}
+
+// CreateTaskCalls gets all the calls that were made to CreateTask.
+// Check the length with:
+//
+//	len(mockedJiraConnector.CreateTaskCalls())
+func (mock *JiraConnectorMock) CreateTaskCalls() []struct {
+	Ctx       context.Context
+	Project   string
+	IssueType string
+	Summary   string
+	Desc      string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Project   string
+		IssueType string
+		Summary   string
+		Desc      string
+	}
+	mock.lockCreateTask.RLock()
+	calls = mock.calls.CreateTask
+	mock.lockCreateTask.RUnlock()
+	return calls
+}
+
+// DoTransition calls DoTransitionFunc.
+func (mock *JiraConnectorMock) DoTransition(ctx context.Context, key string, transitionID string) error {
+	if mock.DoTransitionFunc == nil {
+		panic("JiraConnectorMock.DoTransitionFunc: method is nil but JiraConnector.DoTransition was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		Key          string
+		TransitionID string
+	}{
+		Ctx:          ctx,
+		Key:          key,
+		TransitionID: transitionID,
+	}
+	mock.lockDoTransition.Lock()
+	mock.calls.DoTransition = append(mock.calls.DoTransition, callInfo)
+	mock.lockDoTransition.Unlock()
+	return mock.DoTransitionFunc(ctx, key, transitionID)
+}
+
+// DoTransitionCalls gets all the calls that were made to DoTransition.
+// Check the length with:
+//
+//	len(mockedJiraConnector.DoTransitionCalls())
+func (mock *JiraConnectorMock) DoTransitionCalls() []struct {
+	Ctx          context.Context
+	Key          string
+	TransitionID string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		Key          string
+		TransitionID string
+	}
+	mock.lockDoTransition.RLock()
+	calls = mock.calls.DoTransition
+	mock.lockDoTransition.RUnlock()
+	return calls
+}
+
+// GetExistingKeys calls GetExistingKeysFunc.
+func (mock *JiraConnectorMock) GetExistingKeys(ctx context.Context, keys []string) (map[string]bool, error) {
+	if mock.GetExistingKeysFunc == nil {
+		panic("JiraConnectorMock.GetExistingKeysFunc: method is nil but JiraConnector.GetExistingKeys was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Keys []string
+	}{
+		Ctx:  ctx,
+		Keys: keys,
+	}
+	mock.lockGetExistingKeys.Lock()
+	mock.calls.GetExistingKeys = append(mock.calls.GetExistingKeys, callInfo)
+	mock.lockGetExistingKeys.Unlock()
+	return mock.GetExistingKeysFunc(ctx, keys)
+}
+
+// GetExistingKeysCalls gets all the calls that were made to GetExistingKeys.
+// Check the length with:
+//
+//	len(mockedJiraConnector.GetExistingKeysCalls())
+func (mock *JiraConnectorMock) GetExistingKeysCalls() []struct {
+	Ctx  context.Context
+	Keys []string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Keys []string
+	}
+	mock.lockGetExistingKeys.RLock()
+	calls = mock.calls.GetExistingKeys
+	mock.lockGetExistingKeys.RUnlock()
+	return calls
+}
+
+// GetLastSprint calls GetLastSprintFunc.
+func (mock *JiraConnectorMock) GetLastSprint(ctx context.Context) (*jira.Sprint, error) {
+	if mock.GetLastSprintFunc == nil {
+		panic("JiraConnectorMock.GetLastSprintFunc: method is nil but JiraConnector.GetLastSprint was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetLastSprint.Lock()
+	mock.calls.GetLastSprint = append(mock.calls.GetLastSprint, callInfo)
+	mock.lockGetLastSprint.Unlock()
+	return mock.GetLastSprintFunc(ctx)
+}
+
+// GetLastSprintCalls gets all the calls that were made to GetLastSprint.
+// Check the length with:
+//
+//	len(mockedJiraConnector.GetLastSprintCalls())
+func (mock *JiraConnectorMock) GetLastSprintCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetLastSprint.RLock()
+	calls = mock.calls.GetLastSprint
+	mock.lockGetLastSprint.RUnlock()
+	return calls
+}
+
+// GetSelf calls GetSelfFunc.
+func (mock *JiraConnectorMock) GetSelf(ctx context.Context) (*jira.User, error) {
+	if mock.GetSelfFunc == nil {
+		panic("JiraConnectorMock.GetSelfFunc: method is nil but JiraConnector.GetSelf was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetSelf.Lock()
+	mock.calls.GetSelf = append(mock.calls.GetSelf, callInfo)
+	mock.lockGetSelf.Unlock()
+	return mock.GetSelfFunc(ctx)
+}
+
+// GetSelfCalls gets all the calls that were made to GetSelf.
+// Check the length with:
+//
+//	len(mockedJiraConnector.GetSelfCalls())
+func (mock *JiraConnectorMock) GetSelfCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetSelf.RLock()
+	calls = mock.calls.GetSelf
+	mock.lockGetSelf.RUnlock()
+	return calls
+}
+
+// GetTasks calls GetTasksFunc.
+func (mock *JiraConnectorMock) GetTasks(ctx context.Context, jql string) (map[string]*jira.Task, error) {
+	if mock.GetTasksFunc == nil {
+		panic("JiraConnectorMock.GetTasksFunc: method is nil but JiraConnector.GetTasks was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Jql string
+	}{
+		Ctx: ctx,
+		Jql: jql,
+	}
+	mock.lockGetTasks.Lock()
+	mock.calls.GetTasks = append(mock.calls.GetTasks, callInfo)
+	mock.lockGetTasks.Unlock()
+	return mock.GetTasksFunc(ctx, jql)
+}
+
+// GetTasksCalls gets all the calls that were made to GetTasks.
+// Check the length with:
+//
+//	len(mockedJiraConnector.GetTasksCalls())
+func (mock *JiraConnectorMock) GetTasksCalls() []struct {
+	Ctx context.Context
+	Jql string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Jql string
+	}
+	mock.lockGetTasks.RLock()
+	calls = mock.calls.GetTasks
+	mock.lockGetTasks.RUnlock()
+	return calls
+}
+
+// GetTransitions calls GetTransitionsFunc.
+func (mock *JiraConnectorMock) GetTransitions(ctx context.Context, key string) ([]*jira.Transition, error) {
+	if mock.GetTransitionsFunc == nil {
+		panic("JiraConnectorMock.GetTransitionsFunc: method is nil but JiraConnector.GetTransitions was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockGetTransitions.Lock()
+	mock.calls.GetTransitions = append(mock.calls.GetTransitions, callInfo)
+	mock.lockGetTransitions.Unlock()
+	return mock.GetTransitionsFunc(ctx, key)
+}
+
+// GetTransitionsCalls gets all the calls that were made to GetTransitions.
+// Check the length with:
+//
+//	len(mockedJiraConnector.GetTransitionsCalls())
+func (mock *JiraConnectorMock) GetTransitionsCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockGetTransitions.RLock()
+	calls = mock.calls.GetTransitions
+	mock.lockGetTransitions.RUnlock()
+	return calls
+}
+
+// GetWatchers calls GetWatchersFunc.
+func (mock *JiraConnectorMock) GetWatchers(ctx context.Context, key string) ([]*jira.User, error) {
+	if mock.GetWatchersFunc == nil {
+		panic("JiraConnectorMock.GetWatchersFunc: method is nil but JiraConnector.GetWatchers was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockGetWatchers.Lock()
+	mock.calls.GetWatchers = append(mock.calls.GetWatchers, callInfo)
+	mock.lockGetWatchers.Unlock()
+	return mock.GetWatchersFunc(ctx, key)
+}
+
+// GetWatchersCalls gets all the calls that were made to GetWatchers.
+// Check the length with:
+//
+//	len(mockedJiraConnector.GetWatchersCalls())
+func (mock *JiraConnectorMock) GetWatchersCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockGetWatchers.RLock()
+	calls = mock.calls.GetWatchers
+	mock.lockGetWatchers.RUnlock()
+	return calls
+}
+
+// RegisterWebhook calls RegisterWebhookFunc.
+func (mock *JiraConnectorMock) RegisterWebhook(ctx context.Context, name string, callbackURL string, jql string) error {
+	if mock.RegisterWebhookFunc == nil {
+		panic("JiraConnectorMock.RegisterWebhookFunc: method is nil but JiraConnector.RegisterWebhook was just called")
 	}
 	callInfo := struct {
+		Ctx         context.Context
+		Name        string
+		CallbackURL string
+		Jql         string
+	}{
+		Ctx:         ctx,
+		Name:        name,
+		CallbackURL: callbackURL,
+		Jql:         jql,
+	}
+	mock.lockRegisterWebhook.Lock()
+	mock.calls.RegisterWebhook = append(mock.calls.RegisterWebhook, callInfo)
+	mock.lockRegisterWebhook.Unlock()
+	return mock.RegisterWebhookFunc(ctx, name, callbackURL, jql)
+}
+
+// RegisterWebhookCalls gets all the calls that were made to RegisterWebhook.
+// Check the length with:
+//
+//	len(mockedJiraConnector.RegisterWebhookCalls())
+func (mock *JiraConnectorMock) RegisterWebhookCalls() []struct {
+	Ctx         context.Context
+	Name        string
+	CallbackURL string
+	Jql         string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		Name        string
+		CallbackURL string
+		Jql         string
+	}
+	mock.lockRegisterWebhook.RLock()
+	calls = mock.calls.RegisterWebhook
+	mock.lockRegisterWebhook.RUnlock()
+	return calls
+}
+
+// ResolveEpics calls ResolveEpicsFunc.
+func (mock *JiraConnectorMock) ResolveEpics(ctx context.Context, tasks map[string]*jira.Task) error {
+	if mock.ResolveEpicsFunc == nil {
+		panic("JiraConnectorMock.ResolveEpicsFunc: method is nil but JiraConnector.ResolveEpics was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Tasks map[string]*jira.Task
+	}{
+		Ctx:   ctx,
+		Tasks: tasks,
+	}
+	mock.lockResolveEpics.Lock()
+	mock.calls.ResolveEpics = append(mock.calls.ResolveEpics, callInfo)
+	mock.lockResolveEpics.Unlock()
+	return mock.ResolveEpicsFunc(ctx, tasks)
+}
+
+// ResolveEpicsCalls gets all the calls that were made to ResolveEpics.
+// Check the length with:
+//
+//	len(mockedJiraConnector.ResolveEpicsCalls())
+func (mock *JiraConnectorMock) ResolveEpicsCalls() []struct {
+	Ctx   context.Context
+	Tasks map[string]*jira.Task
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Tasks map[string]*jira.Task
+	}
+	mock.lockResolveEpics.RLock()
+	calls = mock.calls.ResolveEpics
+	mock.lockResolveEpics.RUnlock()
+	return calls
+}
+
+// ValidateJQL calls ValidateJQLFunc.
+func (mock *JiraConnectorMock) ValidateJQL(ctx context.Context, jql string) error {
+	if mock.ValidateJQLFunc == nil {
+		panic("JiraConnectorMock.ValidateJQLFunc: method is nil but JiraConnector.ValidateJQL was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
 		Jql string
 	}{
+		Ctx: ctx,
 		Jql: jql,
 	}
-	mock.lockGetUserTasks.Lock()
-	mock.calls.GetUserTasks = append(mock.calls.GetUserTasks, callInfo)
-	mock.lockGetUserTasks.Unlock()
-	return mock.GetUserTasksFunc(jql)
+	mock.lockValidateJQL.Lock()
+	mock.calls.ValidateJQL = append(mock.calls.ValidateJQL, callInfo)
+	mock.lockValidateJQL.Unlock()
+	return mock.ValidateJQLFunc(ctx, jql)
 }
 
-// GetUserTasksCalls gets all the calls that were made to GetUserTasks.
+// ValidateJQLCalls gets all the calls that were made to ValidateJQL.
 // Check the length with:
-//     len(mockedJiraConnector.GetUserTasksCalls())
-func (mock *JiraConnectorMock) GetUserTasksCalls() []struct {
+//
+//	len(mockedJiraConnector.ValidateJQLCalls())
+func (mock *JiraConnectorMock) ValidateJQLCalls() []struct {
+	Ctx context.Context
 	Jql string
 } {
 	var calls []struct {
+		Ctx context.Context
 		Jql string
 	}
-	mock.lockGetUserTasks.RLock()
-	calls = mock.calls.GetUserTasks
-	mock.lockGetUserTasks.RUnlock()
+	mock.lockValidateJQL.RLock()
+	calls = mock.calls.ValidateJQL
+	mock.lockValidateJQL.RUnlock()
 	return calls
 }
@@ -0,0 +1,144 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"text/template"
+	"time"
+)
+
+const (
+	currentUserJQL = "currentUser()"
+	// webhookFromJQL and webhookToJQL are dates of sync query in webhook filter, which is kept for a long time,
+	// so the dates are relative.
+	webhookFromJQL = "startOfDay(-7d)"
+	webhookToJQL   = "startOfDay(1d)"
+
+	defaultSyncJQL = "assignee = {{ .User }} AND status not in (done, closed, close, resolved) " +
+		"ORDER BY priority DESC, updated DESC"
+	defaultWeeklyJQL = "assignee = {{ .User }} AND (resolutiondate >= {{ .From }} AND resolutiondate < {{ .To }} " +
+		"OR status not in (done, closed, close, resolved) AND created < {{ .To }}) " +
+		"ORDER BY resolutiondate DESC"
+)
+
+// orderByRe matches ORDER BY clause at the end of query.
+var orderByRe = regexp.MustCompile(`(?is)\s*\bORDER\s+BY\b.*$`)
+
+// JQLConfig keeps per command JQL templates from `jql` config section.
+// Templates may use {{ .User }}, {{ .Since }}, {{ .From }} and {{ .To }} placeholders,
+// values are already quoted, so `assignee = {{ .User }}` can be replaced by any other condition,
+// e.g. `reviewer = {{ .User }}` or `watcher = {{ .User }}`.
+type JQLConfig struct {
+	Sync   string
+	Weekly string
+}
+
+// jqlParams are values available in JQL templates.
+type jqlParams struct {
+	// User is currentUser() or quoted username of team member.
+	User string
+	// Since is an alias of From.
+	Since string
+	From  string
+	To    string
+}
+
+func newJQLParams(user string, dateRange DateRange) jqlParams {
+	if user == "" {
+		user = currentUserJQL
+	} else {
+		user = jqlQuote(user)
+	}
+
+	from := jqlQuote(dateRange.From.Format(jqlDateLayout))
+
+	return jqlParams{
+		User:  user,
+		Since: from,
+		From:  from,
+		To:    jqlQuote(dateRange.To.Format(jqlDateLayout)),
+	}
+}
+
+// syncJQL returns query for sync command, {{ .Since }} is set to the start of last 7 days.
+func (c JQLConfig) syncJQL(now time.Time) (string, error) {
+	tmpl := c.Sync
+	if tmpl == "" {
+		tmpl = defaultSyncJQL
+	}
+
+	return renderJQL("sync", tmpl, newJQLParams("", defaultDateRange(now)))
+}
+
+// webhookJQL returns filter of Jira webhook, it's sync query of the user, since webhook runs without user.
+// Issues once assigned to the user are added, so events of issues reassigned to someone else or resolved are
+// sent too, and their cards are completed.
+func (c JQLConfig) webhookJQL(user string) (string, error) {
+	tmpl := c.Sync
+	if tmpl == "" {
+		tmpl = defaultSyncJQL
+	}
+
+	query, err := renderJQL("sync", tmpl, jqlParams{
+		User:  jqlQuote(user),
+		Since: webhookFromJQL,
+		From:  webhookFromJQL,
+		To:    webhookToJQL,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("(%s) OR assignee WAS %s", orderByRe.ReplaceAllString(query, ""), jqlQuote(user)), nil
+}
+
+// weeklyJQL returns query for weekly report of the user, empty user means current user.
+func (c JQLConfig) weeklyJQL(dateRange DateRange, user string) (string, error) {
+	tmpl := c.Weekly
+	if tmpl == "" {
+		tmpl = defaultWeeklyJQL
+	}
+
+	return renderJQL("weekly", tmpl, newJQLParams(user, dateRange))
+}
+
+// checkWeeklyUser returns error if weekly query doesn't depend on the user, so it can't be used for team report.
+func (c JQLConfig) checkWeeklyUser(dateRange DateRange) error {
+	first, err := c.weeklyJQL(dateRange, "first")
+	if err != nil {
+		return err
+	}
+
+	second, err := c.weeklyJQL(dateRange, "second")
+	if err != nil {
+		return err
+	}
+
+	if first == second {
+		return errors.New("`weekly` JQL template doesn't use {{ .User }}, so team members would get the same tasks")
+	}
+
+	return nil
+}
+
+func renderJQL(name, tmpl string, params jqlParams) (string, error) {
+	t, err := template.New(name).Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("can't parse `%s` JQL template: %w", name, err)
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := t.Execute(buf, params); err != nil {
+		return "", fmt.Errorf("can't render `%s` JQL template: %w", name, err)
+	}
+
+	return buf.String(), nil
+}
+
+// issueJQL narrows query down to the issue, ORDER BY clause of the query is dropped.
+func issueJQL(key, query string) string {
+	return fmt.Sprintf("issuekey = %s AND (%s)", jqlQuote(key), orderByRe.ReplaceAllString(query, ""))
+}
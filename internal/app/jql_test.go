@@ -0,0 +1,131 @@
+package app
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestJQLConfig(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+
+	week, err := parseISOWeek("2026-W41", time.UTC)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		render  func(c JQLConfig) (string, error)
+		config  JQLConfig
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "default sync",
+			render: func(c JQLConfig) (string, error) {
+				return c.syncJQL(now)
+			},
+			want: "assignee = currentUser() AND status not in (done, closed, close, resolved) " +
+				"ORDER BY priority DESC, updated DESC",
+		},
+		{
+			name: "sync without assignee",
+			render: func(c JQLConfig) (string, error) {
+				return c.syncJQL(now)
+			},
+			config: JQLConfig{Sync: "(reviewer = {{ .User }} OR watcher = {{ .User }}) AND updated >= {{ .Since }}"},
+			want:   `(reviewer = currentUser() OR watcher = currentUser()) AND updated >= "2026-10-07 00:00"`,
+		},
+		{
+			name: "default webhook",
+			render: func(c JQLConfig) (string, error) {
+				return c.webhookJQL("5b10ac8d82e05b22cc7d4ef5")
+			},
+			want: `(assignee = "5b10ac8d82e05b22cc7d4ef5" AND status not in (done, closed, close, resolved)) ` +
+				`OR assignee WAS "5b10ac8d82e05b22cc7d4ef5"`,
+		},
+		{
+			name: "webhook with dates",
+			render: func(c JQLConfig) (string, error) {
+				return c.webhookJQL("alice")
+			},
+			config: JQLConfig{Sync: "reviewer = {{ .User }} AND updated >= {{ .Since }} ORDER BY updated"},
+			want:   `(reviewer = "alice" AND updated >= startOfDay(-7d)) OR assignee WAS "alice"`,
+		},
+		{
+			name: "default weekly",
+			render: func(c JQLConfig) (string, error) {
+				return c.weeklyJQL(week, "")
+			},
+			want: `assignee = currentUser() AND (resolutiondate >= "2026-10-05 00:00" ` +
+				`AND resolutiondate < "2026-10-12 00:00" ` +
+				`OR status not in (done, closed, close, resolved) AND created < "2026-10-12 00:00") ` +
+				`ORDER BY resolutiondate DESC`,
+		},
+		{
+			name: "weekly for team member",
+			render: func(c JQLConfig) (string, error) {
+				return c.weeklyJQL(week, "alice")
+			},
+			config: JQLConfig{Weekly: "component = Backend AND assignee = {{ .User }}"},
+			want:   `component = Backend AND assignee = "alice"`,
+		},
+		{
+			name: "unknown placeholder",
+			render: func(c JQLConfig) (string, error) {
+				return c.syncJQL(now)
+			},
+			config:  JQLConfig{Sync: "assignee = {{ .Owner }}"},
+			wantErr: true,
+		},
+		{
+			name: "invalid template",
+			render: func(c JQLConfig) (string, error) {
+				return c.weeklyJQL(week, "")
+			},
+			config:  JQLConfig{Weekly: "assignee = {{ .User "},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.render(tt.config)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_issueJQL(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "with order",
+			query: "assignee = currentUser() ORDER BY priority DESC, updated DESC",
+			want:  `issuekey = "JIRA1-1" AND (assignee = currentUser())`,
+		},
+		{
+			name:  "lowercase order",
+			query: "assignee = currentUser() order by updated",
+			want:  `issuekey = "JIRA1-1" AND (assignee = currentUser())`,
+		},
+		{
+			name:  "without order",
+			query: "reviewer = currentUser() OR watcher = currentUser()",
+			want:  `issuekey = "JIRA1-1" AND (reviewer = currentUser() OR watcher = currentUser())`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, issueJQL("JIRA1-1", tt.query))
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"log/slog"
+	"strings"
+)
+
+const (
+	roleAssignee = "assignee"
+	roleReporter = "reporter"
+	roleWatchers = "watchers"
+
+	autoMatchEmail    = "email"
+	autoMatchFullName = "fullname"
+)
+
+var defaultMemberRoles = []string{roleAssignee, roleReporter, roleWatchers}
+
+// memberMapper maps Jira users to Trello board members.
+type memberMapper struct {
+	roles     map[string]bool
+	autoMatch string
+	// users maps lower case Jira username to Trello member ID.
+	users   map[string]string
+	byEmail map[string]string
+	byName  map[string]string
+	// managed keeps IDs of members, which can be matched to Jira users, sync adds and removes only these members,
+	// other members added to cards manually are kept.
+	managed map[string]bool
+}
+
+func newMemberMapper(cfg *trello.MemberMapping, members map[string]*trello.Member) *memberMapper {
+	m := &memberMapper{
+		roles:     map[string]bool{},
+		autoMatch: strings.ToLower(cfg.AutoMatch),
+		users:     map[string]string{},
+		byEmail:   map[string]string{},
+		byName:    map[string]string{},
+		managed:   map[string]bool{},
+	}
+
+	roles := cfg.Roles
+	if len(roles) == 0 {
+		roles = defaultMemberRoles
+	}
+
+	for _, role := range roles {
+		m.roles[strings.ToLower(role)] = true
+	}
+
+	// Auto matched members are managed even if they aren't on current tasks, so they are removed from cards
+	// of tasks reassigned to other users.
+	for _, member := range members {
+		if member.Email != "" {
+			m.byEmail[strings.ToLower(member.Email)] = member.ID
+
+			if m.autoMatch == autoMatchEmail {
+				m.managed[member.ID] = true
+			}
+		}
+
+		if member.FullName != "" {
+			m.byName[strings.ToLower(member.FullName)] = member.ID
+
+			if m.autoMatch == autoMatchFullName {
+				m.managed[member.ID] = true
+			}
+		}
+	}
+
+	// Config keys are case-insensitive, so Jira usernames are compared in lower case.
+	for jiraUser, trelloMember := range cfg.Users {
+		id := trelloMember
+		if member, ok := members[trelloMember]; ok {
+			id = member.ID
+		}
+
+		m.users[strings.ToLower(jiraUser)] = id
+		m.managed[id] = true
+	}
+
+	return m
+}
+
+// memberID returns Trello member ID of Jira user.
+func (m *memberMapper) memberID(user *jira.User) (string, bool) {
+	if user == nil {
+		return "", false
+	}
+
+	if id, ok := m.users[strings.ToLower(user.Name)]; ok {
+		return id, true
+	}
+
+	var (
+		id string
+		ok bool
+	)
+
+	switch m.autoMatch {
+	case autoMatchEmail:
+		id, ok = m.byEmail[strings.ToLower(user.Email)]
+	case autoMatchFullName:
+		id, ok = m.byName[strings.ToLower(user.DisplayName)]
+	}
+
+	if ok && id != "" {
+		return id, true
+	}
+
+	return "", false
+}
+
+// taskMembers returns Trello member IDs of task users in configured roles.
+func (m *memberMapper) taskMembers(task *jira.Task) []string {
+	users := make([]*jira.User, 0)
+
+	if m.roles[roleAssignee] {
+		users = append(users, task.Assignee)
+	}
+
+	if m.roles[roleReporter] {
+		users = append(users, task.Reporter)
+	}
+
+	if m.roles[roleWatchers] {
+		users = append(users, task.Watchers...)
+	}
+
+	res := make([]string, 0, len(users))
+
+	for _, user := range users {
+		if id, ok := m.memberID(user); ok {
+			res = append(res, id)
+		}
+	}
+
+	return res
+}
+
+// cardMembers returns card members with current user, mapped task users and members,
+// which are added to the card manually.
+func (m *memberMapper) cardMembers(userID string, task *jira.Task, current []string) []string {
+	res := make([]string, 0)
+	seen := map[string]bool{}
+
+	add := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			res = append(res, id)
+		}
+	}
+
+	add(userID)
+
+	desired := m.taskMembers(task)
+
+	for _, id := range current {
+		if !m.managed[id] {
+			add(id)
+		}
+	}
+
+	for _, id := range desired {
+		add(id)
+	}
+
+	return res
+}
+
+// prepareMembers creates member mapper and loads watchers, when member mapping is enabled.
+func (s *SyncService) prepareMembers(ctx context.Context) error {
+	cfg := s.tCli.GetConfig().Members
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	members, err := s.tCli.GetMembers(ctx)
+	if err != nil {
+		return fmt.Errorf("can't get board members: %w", err)
+	}
+
+	s.members = newMemberMapper(cfg, members)
+
+	if s.members.roles[roleWatchers] {
+		for key, task := range s.jTasks {
+			if task.Watchers, err = s.jCli.GetWatchers(ctx, key); err != nil {
+				return fmt.Errorf("can't get watchers of `%s`: %w", key, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// cardMembers returns comma separated members of card for the task.
+func (s *SyncService) cardMembers(task *jira.Task, tCard *trello.Card) string {
+	userID := s.tCli.GetConfig().UserID
+
+	if s.members == nil {
+		if tCard != nil {
+			return tCard.IDMembers
+		}
+
+		return userID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var current []string
+	if tCard != nil && tCard.IDMembers != "" {
+		current = strings.Split(tCard.IDMembers, ",")
+	}
+
+	return strings.Join(s.members.cardMembers(userID, task, current), ",")
+}
+
+func (s *SyncService) updateCardMembers(ctx context.Context, tCard *trello.Card, task *jira.Task) error {
+	members := s.cardMembers(task, tCard)
+
+	if sameMembers(tCard.IDMembers, members) {
+		return nil
+	}
+
+	slog.Info("Updating card members", "key", tCard.Key)
+
+	if err := s.tCli.UpdateCardMembers(ctx, tCard.ID, members); err != nil {
+		return fmt.Errorf("can't update members on card `%s`: %w", tCard.Key, err)
+	}
+
+	return nil
+}
+
+func sameMembers(a, b string) bool {
+	aMembers := strings.Split(a, ",")
+	bMembers := strings.Split(b, ",")
+
+	if len(aMembers) != len(bMembers) {
+		return false
+	}
+
+	set := map[string]bool{}
+	for _, id := range aMembers {
+		set[id] = true
+	}
+
+	for _, id := range bMembers {
+		if !set[id] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,132 @@
+package app
+
+import (
+	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var testBoardMembers = map[string]*trello.Member{
+	"alice": {Name: "alice", FullName: "Alice Smith", ID: "aaa", Email: "alice@example.com"},
+	"bob":   {Name: "bob", FullName: "Bob Jones", ID: "bbb", Email: "bob@example.com"},
+	"carol": {Name: "carol", FullName: "Carol White", ID: "ccc"},
+}
+
+func Test_memberMapper_memberID(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    *trello.MemberMapping
+		user   *jira.User
+		wantID string
+		wantOK bool
+	}{
+		{
+			name:   "explicit username",
+			cfg:    &trello.MemberMapping{Users: map[string]string{"asmith": "alice"}},
+			user:   &jira.User{Name: "ASmith"},
+			wantID: "aaa",
+			wantOK: true,
+		},
+		{
+			name:   "explicit member ID",
+			cfg:    &trello.MemberMapping{Users: map[string]string{"carol.w": "ccc"}},
+			user:   &jira.User{Name: "carol.w"},
+			wantID: "ccc",
+			wantOK: true,
+		},
+		{
+			name:   "auto match by email",
+			cfg:    &trello.MemberMapping{AutoMatch: "email"},
+			user:   &jira.User{Name: "bjones", Email: "BOB@example.com"},
+			wantID: "bbb",
+			wantOK: true,
+		},
+		{
+			name:   "auto match by full name",
+			cfg:    &trello.MemberMapping{AutoMatch: "fullname"},
+			user:   &jira.User{Name: "cw", DisplayName: "carol white"},
+			wantID: "ccc",
+			wantOK: true,
+		},
+		{
+			name: "no auto match",
+			cfg:  &trello.MemberMapping{},
+			user: &jira.User{Name: "bjones", Email: "bob@example.com"},
+		},
+		{
+			name: "unknown user",
+			cfg:  &trello.MemberMapping{AutoMatch: "email"},
+			user: &jira.User{Name: "dave", Email: "dave@example.com"},
+		},
+		{
+			name: "no user",
+			cfg:  &trello.MemberMapping{AutoMatch: "email"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, ok := newMemberMapper(tt.cfg, testBoardMembers).memberID(tt.user)
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.wantID, id)
+		})
+	}
+}
+
+func Test_memberMapper_cardMembers(t *testing.T) {
+	task := &jira.Task{
+		Assignee: &jira.User{Name: "bjones", Email: "bob@example.com"},
+		Reporter: &jira.User{Name: "asmith", Email: "alice@example.com"},
+		Watchers: []*jira.User{
+			{Name: "asmith", Email: "alice@example.com"},
+			{Name: "cw", Email: "carol@example.com"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		cfg     *trello.MemberMapping
+		current []string
+		want    []string
+	}{
+		{
+			name: "new card with all roles",
+			cfg:  &trello.MemberMapping{AutoMatch: "email", Users: map[string]string{"cw": "carol"}},
+			want: []string{"me", "bbb", "aaa", "ccc"},
+		},
+		{
+			name: "assignee only",
+			cfg:  &trello.MemberMapping{AutoMatch: "email", Roles: []string{"Assignee"}},
+			want: []string{"me", "bbb"},
+		},
+		{
+			name:    "manually added member is kept",
+			cfg:     &trello.MemberMapping{AutoMatch: "email", Roles: []string{"assignee"}},
+			current: []string{"me", "zzz"},
+			want:    []string{"me", "zzz", "bbb"},
+		},
+		{
+			name:    "auto matched member of other task is removed",
+			cfg:     &trello.MemberMapping{AutoMatch: "fullname", Roles: []string{"assignee"}},
+			current: []string{"me", "aaa", "zzz"},
+			want:    []string{"me", "zzz"},
+		},
+		{
+			name:    "previous assignee is removed",
+			cfg:     &trello.MemberMapping{Roles: []string{"assignee"}, Users: map[string]string{"asmith": "alice", "bjones": "bob"}},
+			current: []string{"me", "aaa"},
+			want:    []string{"me", "bbb"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, newMemberMapper(tt.cfg, testBoardMembers).cardMembers("me", task, tt.current))
+		})
+	}
+}
+
+func Test_sameMembers(t *testing.T) {
+	require.True(t, sameMembers("a,b", "b,a"))
+	require.False(t, sameMembers("a,b", "a"))
+	require.False(t, sameMembers("a,b", "a,c"))
+}
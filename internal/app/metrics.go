@@ -0,0 +1,90 @@
+package app
+
+import "github.com/Brialius/jira2trello/internal/metrics"
+
+// Error kinds of sync counted by jira2trello_errors_total.
+const (
+	errorSync    = "sync"
+	errorCard    = "card"
+	errorWebhook = "webhook"
+)
+
+// defaultSyncMetrics are metrics of syncs run by serve command.
+var defaultSyncMetrics = newSyncMetrics(metrics.Default, metrics.Errors)
+
+// syncMetrics counts cards changed by syncs and keeps state of the last sync.
+type syncMetrics struct {
+	created     *metrics.Counter
+	moved       *metrics.Counter
+	relabeled   *metrics.Counter
+	completed   *metrics.Counter
+	syncs       *metrics.Counter
+	errors      *metrics.Counter
+	lastSuccess *metrics.Gauge
+	issues      *metrics.Gauge
+}
+
+func newSyncMetrics(r *metrics.Registry, errors *metrics.Counter) *syncMetrics {
+	return &syncMetrics{
+		created:   r.NewCounter("jira2trello_cards_created_total", "Cards created for Jira issues."),
+		moved:     r.NewCounter("jira2trello_cards_moved_total", "Cards moved to other lists, except `Done` list."),
+		relabeled: r.NewCounter("jira2trello_cards_relabeled_total", "Cards with updated labels."),
+		completed: r.NewCounter("jira2trello_cards_completed_total", "Cards moved to `Done` list."),
+		syncs: r.NewCounter("jira2trello_syncs_total",
+			"Syncs by type, `full` or `issue` triggered by webhook, and result, `success` or `failure`.",
+			"type", "result"),
+		errors: errors,
+		lastSuccess: r.NewGauge("jira2trello_last_success_timestamp_seconds",
+			"Unix time of the last full sync finished without errors."),
+		issues: r.NewGauge("jira2trello_issues_fetched", "Jira issues fetched by the last full sync."),
+	}
+}
+
+// observe counts changes and errors of the finished sync.
+func (m *syncMetrics) observe(run *syncRun) {
+	res := run.Result
+
+	m.created.Add(float64(len(res.Created)))
+	m.relabeled.Add(float64(len(res.Relabeled)))
+
+	for _, change := range res.Moved {
+		if change.List == "Done" {
+			m.completed.Inc()
+		} else {
+			m.moved.Inc()
+		}
+	}
+
+	for _, e := range res.Errors {
+		if e.Key == "" {
+			m.errors.Inc(errorSync)
+		} else {
+			m.errors.Inc(errorCard)
+		}
+	}
+
+	typ := "full"
+	if run.Key != "" {
+		typ = "issue"
+	}
+
+	result := "success"
+	if len(res.Errors) > 0 {
+		result = "failure"
+	}
+
+	m.syncs.Inc(typ, result)
+
+	if run.Key != "" {
+		return
+	}
+
+	// Issues aren't set if sync failed before fetching them, then the last value is kept.
+	if len(res.Issues) > 0 || len(res.Errors) == 0 {
+		m.issues.Set(float64(len(res.Issues)))
+	}
+
+	if len(res.Errors) == 0 {
+		m.lastSuccess.Set(float64(run.Finished.Unix()))
+	}
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/metrics"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeMetrics(t *testing.T, r *metrics.Registry) string {
+	t.Helper()
+
+	out := &bytes.Buffer{}
+	_, err := r.WriteTo(out)
+	require.NoError(t, err)
+
+	return out.String()
+}
+
+func TestSyncMetrics_observe(t *testing.T) {
+	r := metrics.NewRegistry()
+	errs := r.NewCounter("test_errors_total", "Errors.", "kind")
+	m := newSyncMetrics(r, errs)
+
+	finished := time.Unix(1700000000, 0)
+
+	m.observe(&syncRun{Finished: finished, Result: &syncResult{
+		Issues:    []*Task{{Key: "JIRA1-1"}, {Key: "JIRA1-2"}},
+		Created:   []*cardChange{{Key: "JIRA1-1", List: "Todo"}},
+		Moved:     []*cardChange{{Key: "JIRA1-2", List: "Doing"}, {Key: "JIRA1-3", List: "Done"}},
+		Relabeled: []*cardChange{{Key: "JIRA1-2"}},
+	}})
+
+	// Failed syncs don't change the last success and the number of issues.
+	m.observe(&syncRun{Finished: finished.Add(time.Minute), Result: &syncResult{
+		Errors: []*syncError{{Error: "can't get jira tasks"}},
+	}})
+
+	m.observe(&syncRun{Key: "JIRA1-4", Finished: finished.Add(2 * time.Minute), Result: &syncResult{
+		Issues: []*Task{{Key: "JIRA1-4"}},
+		Moved:  []*cardChange{{Key: "JIRA1-4", List: "Done"}},
+		Errors: []*syncError{{Key: "JIRA1-4", Error: "can't update labels"}},
+	}})
+
+	out := writeMetrics(t, r)
+
+	for _, line := range []string{
+		"jira2trello_cards_created_total 1",
+		"jira2trello_cards_moved_total 1",
+		"jira2trello_cards_relabeled_total 1",
+		"jira2trello_cards_completed_total 2",
+		`jira2trello_syncs_total{type="full",result="success"} 1`,
+		`jira2trello_syncs_total{type="full",result="failure"} 1`,
+		`jira2trello_syncs_total{type="issue",result="failure"} 1`,
+		"jira2trello_last_success_timestamp_seconds 1.7e+09",
+		"jira2trello_issues_fetched 2",
+		`test_errors_total{kind="sync"} 1`,
+		`test_errors_total{kind="card"} 1`,
+	} {
+		require.Contains(t, out, line+"\n")
+	}
+}
+
+func TestServer_metrics(t *testing.T) {
+	srv, jCli, _ := getTestServer(t)
+
+	r := metrics.NewRegistry()
+	srv.metrics = newSyncMetrics(r, r.NewCounter("test_errors_total", "Errors.", "kind"))
+
+	srv.mu.Lock()
+	srv.run(context.Background(), "")
+
+	jCli.GetTasksFunc = func(ctx context.Context, jql string) (map[string]*jira.Task, error) {
+		return nil, errors.New("jira is down")
+	}
+
+	srv.run(context.Background(), "")
+	srv.mu.Unlock()
+
+	out := writeMetrics(t, r)
+	require.Contains(t, out, "jira2trello_cards_created_total 1\n")
+	require.Contains(t, out, "jira2trello_cards_moved_total 2\n")
+	require.Contains(t, out, "jira2trello_cards_completed_total 1\n")
+	require.Contains(t, out, "jira2trello_issues_fetched 20\n")
+	require.Contains(t, out, `jira2trello_syncs_total{type="full",result="success"} 1`+"\n")
+	require.Contains(t, out, `jira2trello_syncs_total{type="full",result="failure"} 1`+"\n")
+	require.Contains(t, out, `test_errors_total{kind="sync"} 1`+"\n")
+
+	w := serveRequest(t, srv.handler(context.Background()), http.MethodGet, "/metrics")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), "text/plain"))
+	require.Contains(t, w.Body.String(), "# TYPE jira2trello_cards_created_total counter")
+	require.Contains(t, w.Body.String(), "# TYPE jira2trello_api_requests_total counter")
+}
@@ -0,0 +1,34 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Output formats of command results.
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+// ValidateOutput returns error for unknown output format.
+func ValidateOutput(output string) error {
+	switch output {
+	case OutputText, OutputJSON:
+		return nil
+	}
+
+	return fmt.Errorf("unknown output format `%s`, supported formats: %s, %s", output, OutputText, OutputJSON)
+}
+
+func writeJSON(out io.Writer, value any) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(value); err != nil {
+		return fmt.Errorf("can't encode json: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const defaultWorkers = 4
+
+// cardErrors are errors of card updates by Jira key.
+type cardErrors map[string]error
+
+func (e cardErrors) Error() string {
+	keys := make([]string, 0, len(e))
+
+	for key := range e {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	res := make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		res = append(res, fmt.Sprintf("%s: %s", key, e[key]))
+	}
+
+	return fmt.Sprintf("%d cards failed:\n%s", len(e), strings.Join(res, "\n"))
+}
+
+// runParallel runs job for each key with limited number of workers,
+// errors are aggregated by key, so other cards are updated if one fails.
+// Remaining keys are skipped when context is done.
+func runParallel(ctx context.Context, workers int, keys []string,
+	job func(ctx context.Context, key string) error) error {
+	if workers < 1 {
+		workers = defaultWorkers
+	}
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs = cardErrors{}
+	)
+
+	jobs := make(chan string)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for key := range jobs {
+				if err := job(ctx, key); err != nil {
+					mu.Lock()
+					errs[key] = err
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, key := range keys {
+		if ctx.Err() != nil {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+		case jobs <- key:
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"sync/atomic"
+	"testing"
+)
+
+func Test_runParallel(t *testing.T) {
+	var done int32
+
+	keys := []string{"K-1", "K-2", "K-3", "K-4", "K-5"}
+
+	err := runParallel(context.Background(), 3, keys, func(ctx context.Context, key string) error {
+		atomic.AddInt32(&done, 1)
+
+		if key == "K-2" || key == "K-4" {
+			return errors.New("rate limit")
+		}
+
+		return nil
+	})
+
+	require.Equal(t, int32(5), done)
+	require.Equal(t, cardErrors{"K-2": errors.New("rate limit"), "K-4": errors.New("rate limit")}, err)
+	require.EqualError(t, err, "2 cards failed:\nK-2: rate limit\nK-4: rate limit")
+
+	require.NoError(t, runParallel(context.Background(), 0, []string{"K-1"}, func(ctx context.Context, key string) error {
+		return nil
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done = 0
+	err = runParallel(ctx, 1, []string{"K-1", "K-2"}, func(ctx context.Context, key string) error {
+		atomic.AddInt32(&done, 1)
+
+		return nil
+	})
+
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, int32(0), done)
+}
@@ -22,33 +22,327 @@ THE SOFTWARE.
 package app
 
 import (
+	"cmp"
 	"fmt"
 	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/theme"
 	"io"
 	"sort"
-	"text/tabwriter"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
-func printJiraTasks(out io.Writer, jTasks map[string]*jira.Task) {
-	const padding = 4
+const (
+	// columnGap is a minimal number of spaces between table columns.
+	columnGap = 5
+	// maxSummaryWidth limits summary, when terminal width is unknown.
+	maxSummaryWidth = 70
+	// minSummaryWidth is a summary width, which is kept if table doesn't fit terminal.
+	minSummaryWidth = 20
+)
+
+// defaultTaskColumns are printed, when columns aren't set in options.
+var defaultTaskColumns = []string{"status", "type", "key", "summary", "created", "due", "spent"}
+
+// TableOptions configures task table printed by sync and weekly-report.
+type TableOptions struct {
+	// Columns are printed columns, default columns are printed if it's empty.
+	Columns []string
+	// Sort has columns to sort tasks by, `-` prefix means descending order. Tasks are sorted by creation by default.
+	Sort []string
+	// Status and Type keep only tasks with any of the values, values are case-insensitive.
+	Status []string
+	Type   []string
+	// Overdue keeps only tasks with due date in the past.
+	Overdue bool
+	// Width is terminal width, summary is shrunk to fit it. Summary is limited to 70 chars if width is zero.
+	Width int
+}
+
+// taskColumn is a column of task table, color returns colorized value, value is printed as is if it's nil.
+// Tasks are sorted by compare function or by column values if it's nil.
+type taskColumn struct {
+	value   func(task *jira.Task) string
+	color   func(th *theme.Theme, task *jira.Task, value string) string
+	compare func(a, b *jira.Task) int
+}
+
+var taskColumns = map[string]*taskColumn{
+	"key": {
+		value: func(task *jira.Task) string { return task.Key },
+	},
+	"status": {
+		value: func(task *jira.Task) string { return task.Status },
+		color: func(th *theme.Theme, _ *jira.Task, value string) string { return th.Status(value) },
+	},
+	"type": {
+		value: func(task *jira.Task) string { return task.Type },
+		color: func(th *theme.Theme, _ *jira.Task, value string) string { return th.Type(value) },
+	},
+	"summary": {
+		value: func(task *jira.Task) string { return task.Summary },
+	},
+	"created": {
+		value:   func(task *jira.Task) string { return formatTableDate(task.Created) },
+		compare: func(a, b *jira.Task) int { return a.Created.Compare(b.Created) },
+	},
+	"updated": {
+		value:   func(task *jira.Task) string { return formatTableDate(task.Updated) },
+		compare: func(a, b *jira.Task) int { return a.Updated.Compare(b.Updated) },
+	},
+	"due": {
+		value: func(task *jira.Task) string { return formatTableDate(task.DueDate) },
+		color: func(th *theme.Theme, task *jira.Task, value string) string {
+			return th.DueDate(value, isOverdue(task, time.Now()))
+		},
+		compare: func(a, b *jira.Task) int { return a.DueDate.Compare(b.DueDate) },
+	},
+	"spent": {
+		value:   func(task *jira.Task) string { return fmt.Sprintf("%0.1f", task.TimeSpent.Hours()) },
+		compare: func(a, b *jira.Task) int { return cmp.Compare(a.TimeSpent, b.TimeSpent) },
+	},
+	"parent": {
+		value: func(task *jira.Task) string { return task.ParentKey },
+	},
+	"priority": {
+		value: func(task *jira.Task) string { return task.Priority },
+	},
+}
+
+// TaskColumns returns sorted names of task table columns.
+func TaskColumns() []string {
+	return sortedKeys(taskColumns)
+}
+
+// taskSort is a column to sort tasks by.
+type taskSort struct {
+	column *taskColumn
+	desc   bool
+}
+
+// taskTable prints tasks filtered, sorted and formatted by table options.
+type taskTable struct {
+	opts    TableOptions
+	names   []string
+	columns []*taskColumn
+	sort    []taskSort
+}
+
+func newTaskTable(opts TableOptions) (*taskTable, error) {
+	t := &taskTable{opts: opts}
+
+	names := opts.Columns
+	if len(names) == 0 {
+		names = defaultTaskColumns
+	}
+
+	for _, name := range names {
+		column, err := getTaskColumn(name)
+		if err != nil {
+			return nil, err
+		}
+
+		t.names = append(t.names, normalizeColumnName(name))
+		t.columns = append(t.columns, column)
+	}
+
+	sortBy := opts.Sort
+	if len(sortBy) == 0 {
+		sortBy = []string{"created"}
+	}
 
-	textWriter := new(tabwriter.Writer)
+	for _, name := range sortBy {
+		desc := strings.HasPrefix(name, "-")
 
-	textWriter.Init(out, 0, 0, padding, ' ', tabwriter.FilterHTML+tabwriter.StripEscape)
+		column, err := getTaskColumn(strings.TrimPrefix(name, "-"))
+		if err != nil {
+			return nil, fmt.Errorf("can't sort tasks: %w", err)
+		}
 
+		t.sort = append(t.sort, taskSort{column: column, desc: desc})
+	}
+
+	return t, nil
+}
+
+func getTaskColumn(name string) (*taskColumn, error) {
+	column, ok := taskColumns[normalizeColumnName(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown column `%s`, supported columns: %s", name, strings.Join(TaskColumns(), ", "))
+	}
+
+	return column, nil
+}
+
+func normalizeColumnName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// filter returns tasks matching table filters in table order.
+func (t *taskTable) filter(jTasks map[string]*jira.Task, now time.Time) []*jira.Task {
 	list := make([]*jira.Task, 0, len(jTasks))
 
 	for _, task := range jTasks {
-		list = append(list, task)
+		if t.match(task, now) {
+			list = append(list, task)
+		}
 	}
 
 	sort.Slice(list, func(i, j int) bool {
-		return list[i].Created.Before(list[j].Created)
+		for _, s := range t.sort {
+			res := s.column.compareTasks(list[i], list[j])
+			if s.desc {
+				res = -res
+			}
+
+			if res != 0 {
+				return res < 0
+			}
+		}
+
+		return list[i].Key < list[j].Key
 	})
 
+	return list
+}
+
+// match reports whether task matches table filters.
+func (t *taskTable) match(task *jira.Task, now time.Time) bool {
+	return matchAny(task.Status, t.opts.Status) && matchAny(task.Type, t.opts.Type) &&
+		(!t.opts.Overdue || isOverdue(task, now))
+}
+
+func (c *taskColumn) compareTasks(a, b *jira.Task) int {
+	if c.compare != nil {
+		return c.compare(a, b)
+	}
+
+	return strings.Compare(c.value(a), c.value(b))
+}
+
+// print prints table of tasks, cells are colorized by theme.
+func (t *taskTable) print(out io.Writer, th *theme.Theme, jTasks map[string]*jira.Task) {
+	list := t.filter(jTasks, time.Now())
+	rows := make([][]string, 0, len(list))
+
 	for _, task := range list {
-		_, _ = fmt.Fprintln(textWriter, task.TabString())
+		row := make([]string, 0, len(t.columns))
+
+		for _, column := range t.columns {
+			row = append(row, column.value(task))
+		}
+
+		rows = append(rows, row)
+	}
+
+	widths := columnWidths(rows, len(t.columns))
+	t.fitSummary(widths)
+
+	writeTable(out, rows, widths, func(row, col int, value string) string {
+		if column := t.columns[col]; column.color != nil {
+			return column.color(th, list[row], value)
+		}
+
+		return value
+	})
+}
+
+// fitSummary shrinks summary column, so table fits terminal width.
+func (t *taskTable) fitSummary(widths []int) {
+	total := 0
+
+	for i, width := range widths {
+		total += width
+
+		if i > 0 {
+			total += columnGap
+		}
+	}
+
+	for i, name := range t.names {
+		if name != "summary" {
+			continue
+		}
+
+		switch {
+		case t.opts.Width == 0:
+			widths[i] = min(widths[i], maxSummaryWidth)
+		case total > t.opts.Width:
+			widths[i] = max(min(widths[i], minSummaryWidth), widths[i]-(total-t.opts.Width))
+		}
+	}
+}
+
+// columnWidths returns maximal text width of each of n columns.
+func columnWidths(rows [][]string, n int) []int {
+	widths := make([]int, n)
+
+	for _, row := range rows {
+		for i, value := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(value))
+		}
+	}
+
+	return widths
+}
+
+// writeTable writes rows with cells truncated to column widths, paint returns colorized cell.
+// Columns are aligned by text width, so colors don't break alignment.
+func writeTable(out io.Writer, rows [][]string, widths []int, paint func(row, col int, value string) string) {
+	for i, row := range rows {
+		line := &strings.Builder{}
+
+		for j, value := range row {
+			value = truncate(value, widths[j])
+			line.WriteString(paint(i, j, value))
+
+			if j < len(row)-1 {
+				line.WriteString(strings.Repeat(" ", widths[j]-utf8.RuneCountInString(value)+columnGap))
+			}
+		}
+
+		_, _ = fmt.Fprintln(out, line.String())
+	}
+}
+
+func isOverdue(task *jira.Task, now time.Time) bool {
+	return !task.DueDate.IsZero() && now.After(task.DueDate)
+}
+
+// matchAny reports whether value is equal to any of values ignoring case, empty values match everything.
+func matchAny(value string, values []string) bool {
+	if len(values) == 0 {
+		return true
+	}
+
+	for _, v := range values {
+		if strings.EqualFold(value, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// formatTableDate returns short date, e.g. `18 May 20`, or empty string for zero date.
+func formatTableDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+
+	return date.Format("02 Jan 06")
+}
+
+// truncate cuts string to n runes, the last rune is replaced by ellipsis if string is cut.
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+
+	if n < 1 {
+		return ""
 	}
 
-	_ = textWriter.Flush()
+	return string([]rune(s)[:n-1]) + "…"
 }
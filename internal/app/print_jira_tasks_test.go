@@ -0,0 +1,92 @@
+package app
+
+import (
+	"bytes"
+	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func Test_taskTable(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2020, 5, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	jTasks := map[string]*jira.Task{
+		"JIRA1-1": {Key: "JIRA1-1", Status: "ToDo", Type: "Bug", Summary: "Fix the login page layout on mobile",
+			Created: day(1), Updated: day(5), DueDate: day(10), Priority: "High", ParentKey: "JIRA1-10"},
+		"JIRA1-2": {Key: "JIRA1-2", Status: "In Progress", Type: "Story", Summary: "Add export",
+			Created: day(2), Updated: day(7), TimeSpent: 90 * time.Minute, Priority: "Low"},
+		"JIRA1-3": {Key: "JIRA1-3", Status: "Blocked", Type: "Sub-task", Summary: "Update dependencies",
+			Created: day(3), Updated: day(6), DueDate: day(4), Priority: "Medium"},
+	}
+
+	tests := []struct {
+		name    string
+		opts    TableOptions
+		wantOut string
+		wantErr bool
+	}{
+		{
+			name: "columns",
+			opts: TableOptions{Columns: []string{"key", "Priority", "parent", "spent"}},
+			wantOut: `JIRA1-1     High       JIRA1-10     0.0
+JIRA1-2     Low                     1.5
+JIRA1-3     Medium                  0.0
+`,
+		},
+		{
+			name: "sort",
+			opts: TableOptions{Columns: []string{"key", "due", "updated"}, Sort: []string{"due", "-updated"}},
+			wantOut: `JIRA1-2                   07 May 20
+JIRA1-3     04 May 20     06 May 20
+JIRA1-1     10 May 20     05 May 20
+`,
+		},
+		{
+			name:    "status and type filters",
+			opts:    TableOptions{Columns: []string{"key"}, Status: []string{"todo", "blocked"}, Type: []string{"bug"}},
+			wantOut: "JIRA1-1\n",
+		},
+		{
+			name:    "overdue",
+			opts:    TableOptions{Columns: []string{"key"}, Overdue: true},
+			wantOut: "JIRA1-1\nJIRA1-3\n",
+		},
+		{
+			name: "width",
+			opts: TableOptions{Columns: []string{"key", "summary", "status"}, Width: 45},
+			wantOut: `JIRA1-1     Fix the login page …     ToDo
+JIRA1-2     Add export               In Progress
+JIRA1-3     Update dependencies      Blocked
+`,
+		},
+		{
+			name:    "unknown column",
+			opts:    TableOptions{Columns: []string{"assignee"}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown sort column",
+			opts:    TableOptions{Sort: []string{"-rank"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := newTaskTable(tt.opts)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			out := &bytes.Buffer{}
+			table.print(out, nil, jTasks)
+			require.Equal(t, tt.wantOut, out.String())
+		})
+	}
+}
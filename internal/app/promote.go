@@ -0,0 +1,104 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"log"
+	"slices"
+	"strings"
+)
+
+// maxSummaryLength is the limit of Jira issue summary in characters.
+const maxSummaryLength = 255
+
+// PromoteOptions are Jira project and issue type of issues created from cards.
+type PromoteOptions struct {
+	Project   string
+	IssueType string
+}
+
+// Promote creates Jira issue from Trello card and links the card to the issue, so it's synced as other cards.
+func Promote(ctx context.Context, jCli JiraConnector, tCli TrelloConnector, cardID string, opts PromoteOptions) {
+	if opts.Project == "" || opts.IssueType == "" {
+		log.Fatalf("Jira project and issue type are required")
+	}
+
+	if err := jCli.Connect(ctx); err != nil {
+		log.Fatalf("Can't connect to jira server: %s", err)
+	}
+
+	if err := tCli.Connect(ctx); err != nil {
+		log.Fatalf("Can't connect to trello: %s", err)
+	}
+
+	task, err := promoteCard(ctx, jCli, tCli, cardID, opts)
+	if err != nil {
+		log.Fatalf("Can't promote card: %s", err)
+	}
+
+	fmt.Printf("Card is promoted to %s: %s\n", task.Key, task.Link)
+}
+
+func promoteCard(ctx context.Context, jCli JiraConnector, tCli TrelloConnector, cardID string,
+	opts PromoteOptions) (*jira.Task, error) {
+	card, err := tCli.GetCard(ctx, cardID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get card `%s`: %w", cardID, err)
+	}
+
+	if card.Key != "" {
+		return nil, fmt.Errorf("card is already linked to %s", card.Key)
+	}
+
+	task, err := jCli.CreateTask(ctx, opts.Project, opts.IssueType, issueSummary(card.Name), card.Desc)
+	if err != nil {
+		return nil, fmt.Errorf("can't create jira issue: %w", err)
+	}
+
+	if err := linkPromotedCard(ctx, tCli, card, task); err != nil {
+		return nil, fmt.Errorf("issue %s is created, but card isn't linked to it: %w", task.Key, err)
+	}
+
+	return task, nil
+}
+
+// linkPromotedCard renames the card to `KEY | Summary`, adds Jira label, current user and marker to the card.
+func linkPromotedCard(ctx context.Context, tCli TrelloConnector, card *trello.Card, task *jira.Task) error {
+	cfg := tCli.GetConfig()
+
+	if err := tCli.UpdateCardName(ctx, card.ID, task.Key+" | "+card.Name); err != nil {
+		return fmt.Errorf("can't rename card: %w", err)
+	}
+
+	if !slices.Contains(*card.IDLabels, cfg.Labels.Jira) {
+		labels := append(append([]string{}, *card.IDLabels...), cfg.Labels.Jira)
+		if err := tCli.UpdateCardLabels(ctx, card.ID, strings.Join(labels, ",")); err != nil {
+			return fmt.Errorf("can't update labels: %w", err)
+		}
+	}
+
+	if members := mergeMembers(card.IDMembers, cfg.UserID); members != card.IDMembers {
+		if err := tCli.UpdateCardMembers(ctx, card.ID, members); err != nil {
+			return fmt.Errorf("can't update members: %w", err)
+		}
+	}
+
+	desc := trello.SetMarker(card.Desc, &trello.Marker{Key: task.Key, Link: task.Link, Owner: cfg.UserID})
+	if err := tCli.UpdateCardDesc(ctx, card.ID, desc); err != nil {
+		return fmt.Errorf("can't update description: %w", err)
+	}
+
+	return nil
+}
+
+// issueSummary returns card name cut to the length of Jira issue summary, the card keeps the full name.
+func issueSummary(name string) string {
+	runes := []rune(name)
+	if len(runes) <= maxSummaryLength {
+		return name
+	}
+
+	return string(runes[:maxSummaryLength-1]) + "…"
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func Test_promoteCard(t *testing.T) {
+	const bucketLabel = "131313131313131313131313"
+
+	newMocks := func(card *trello.Card) (*JiraConnectorMock, *TrelloConnectorMock) {
+		jCli := GetJiraMockedCli(nil)
+		jCli.CreateTaskFunc = func(ctx context.Context, project, issueType, summary, desc string) (*jira.Task, error) {
+			return &jira.Task{Key: project + "-7", Link: "https://jira-site/browse/" + project + "-7", Summary: summary}, nil
+		}
+
+		tCli := GetTrelloMockedCli(nil)
+		tCli.GetCardFunc = func(ctx context.Context, cardID string) (*trello.Card, error) {
+			return card, nil
+		}
+		tCli.UpdateCardNameFunc = func(ctx context.Context, cardID, name string) error {
+			return nil
+		}
+		tCli.UpdateCardMembersFunc = func(ctx context.Context, cardID, members string) error {
+			return nil
+		}
+
+		return jCli, tCli
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		jCli, tCli := newMocks(&trello.Card{
+			ID: "c1", Name: "Idea", Desc: "Details", IDLabels: &[]string{bucketLabel}, IDMembers: "222222222222222222222222",
+		})
+
+		task, err := promoteCard(context.Background(), jCli, tCli, "c1", PromoteOptions{Project: "ABC", IssueType: "Task"})
+		require.NoError(t, err)
+		require.Equal(t, "ABC-7", task.Key)
+
+		created := jCli.CreateTaskCalls()
+		require.Len(t, created, 1)
+		require.Equal(t, []string{"ABC", "Task", "Idea", "Details"},
+			[]string{created[0].Project, created[0].IssueType, created[0].Summary, created[0].Desc})
+		require.Equal(t, calls{{"c1", "ABC-7 | Idea"}}, stringCalls(tCli.UpdateCardNameCalls()))
+		require.Equal(t, calls{{"c1", bucketLabel + ",121212121212121212121fa4"}},
+			stringCalls(tCli.UpdateCardLabelsCalls()))
+		require.Equal(t, calls{{"c1", "222222222222222222222222,111111111111111111111111"}},
+			stringCalls(tCli.UpdateCardMembersCalls()))
+		require.Equal(t, calls{{"c1",
+			"Details\n\n[jira2trello]: <https://jira-site/browse/ABC-7> \"key=ABC-7 owner=111111111111111111111111\"",
+		}}, stringCalls(tCli.UpdateCardDescCalls()))
+	})
+
+	t.Run("long name with jira label", func(t *testing.T) {
+		name := strings.Repeat("я", 300)
+		jCli, tCli := newMocks(&trello.Card{ID: "c1", Name: name, IDLabels: &[]string{"121212121212121212121fa4"}})
+
+		_, err := promoteCard(context.Background(), jCli, tCli, "c1", PromoteOptions{Project: "ABC", IssueType: "Task"})
+		require.NoError(t, err)
+
+		created := jCli.CreateTaskCalls()
+		require.Len(t, created, 1)
+		require.Equal(t, strings.Repeat("я", 254)+"…", created[0].Summary)
+		require.Equal(t, calls{{"c1", "ABC-7 | " + name}}, stringCalls(tCli.UpdateCardNameCalls()))
+		require.Empty(t, tCli.UpdateCardLabelsCalls())
+	})
+
+	t.Run("already linked", func(t *testing.T) {
+		jCli, tCli := newMocks(&trello.Card{ID: "c1", Name: "ABC-1 | Task", Key: "ABC-1"})
+
+		_, err := promoteCard(context.Background(), jCli, tCli, "c1", PromoteOptions{Project: "ABC", IssueType: "Task"})
+		require.Error(t, err)
+		require.Empty(t, jCli.CreateTaskCalls())
+	})
+
+	t.Run("card isn't linked", func(t *testing.T) {
+		jCli, tCli := newMocks(&trello.Card{ID: "c1", Name: "Idea", IDLabels: &[]string{}})
+		tCli.UpdateCardNameFunc = func(ctx context.Context, cardID, name string) error {
+			return errors.New("rate limit")
+		}
+
+		_, err := promoteCard(context.Background(), jCli, tCli, "c1", PromoteOptions{Project: "ABC", IssueType: "Task"})
+		require.ErrorContains(t, err, "issue ABC-7 is created")
+	})
+}
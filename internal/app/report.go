@@ -22,14 +22,15 @@ THE SOFTWARE.
 package app
 
 import (
+	"context"
 	"fmt"
+	"github.com/Brialius/jira2trello/internal/trello"
 	"github.com/mattn/go-colorable"
-	"html/template"
 	"io"
 	"log"
 	"os"
+	"path/filepath"
 	"sort"
-	"strconv"
 	"strings"
 	"time"
 )
@@ -41,110 +42,260 @@ const (
 )
 
 type Task struct {
-	Name   string
-	Status string
-	Link   string
-	Key    string
+	Name       string        `json:"name"`
+	Status     string        `json:"status"`
+	Link       string        `json:"link"`
+	Key        string        `json:"key"`
+	Assignee   string        `json:"assignee,omitempty"`
+	Type       string        `json:"type,omitempty"`
+	TimeSpent  time.Duration `json:"timeSpent,omitempty"`
+	ParentKey  string        `json:"parentKey,omitempty"`
+	ParentLink string        `json:"parentLink,omitempty"`
+}
+
+type ReportOptions struct {
+	Format string
+	Weekly bool
+	// Template is a path to user template, it overrides Format.
+	Template string
+	Range    DateRangeOptions
+	// ArchiveDone archives cards in `Done` list after report is saved to file.
+	ArchiveDone bool
+	// Team members to build report for, report is built for current user if empty.
+	Team []TeamMember
+	JQL  JQLConfig
+	// Output json prints report as json to stdout, it overrides Format and Template.
+	Output string
+}
+
+// reportSection is a group of tasks shown under its own header, e.g. tasks of a team member.
+type reportSection struct {
+	Name  string
+	Tasks []*Task
 }
 
 type report struct {
-	HTMLReport   bool
+	Format       string
 	WeeklyReport bool
 	Tasks        []*Task
+	Sections     []*reportSection
 	WeekNumber   int
 	Year         int
+	From         time.Time
+	To           time.Time
+	Sprint       string
+	dateRange    DateRange
+	formatter    reportFormatter
 }
 
-func newReport(htmlReport bool, weeklyReport bool, tasks []*Task) *report {
-	year, week := time.Now().ISOWeek()
+func newReport(opts ReportOptions, dateRange DateRange, tasks []*Task) (*report, error) {
+	var (
+		formatter reportFormatter
+		err       error
+	)
+
+	switch {
+	case opts.Output == OutputJSON:
+		formatter = jsonFormatter{}
+	case opts.Template != "":
+		formatter, err = newTemplateFormatter(opts.Template)
+	default:
+		formatter, err = getReportFormatter(opts.Format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	year, week := dateRange.ISOWeek()
 
 	return &report{
-		HTMLReport:   htmlReport,
-		WeeklyReport: weeklyReport,
+		Format:       opts.Format,
+		WeeklyReport: opts.Weekly,
 		Tasks:        tasks,
 		WeekNumber:   week,
 		Year:         year,
-	}
+		From:         dateRange.From,
+		To:           dateRange.To,
+		Sprint:       dateRange.Sprint,
+		dateRange:    dateRange,
+		formatter:    formatter,
+	}, nil
 }
 
-// Generate report.
-func (r *report) generate(out io.Writer) {
-	if r.HTMLReport {
-		t := template.Must(template.New("report").Parse(htmlTemplate))
-		err := t.Execute(out, r)
+// Title returns report title based on report period.
+func (r *report) Title() string {
+	switch {
+	case r.Sprint != "":
+		return fmt.Sprintf("Report %s (%s)", r.Sprint, r.dateRange)
+	case r.dateRange.weekly:
+		return fmt.Sprintf("Report %d week %d", r.Year, r.WeekNumber)
+	}
 
-		if err != nil {
-			log.Fatalf("can't generate html report: %s", err)
-		}
+	return fmt.Sprintf("Report %s", r.dateRange)
+}
 
-		return
+// sections returns report sections or the only unnamed section with all tasks.
+func (r *report) sections() []*reportSection {
+	if len(r.Sections) > 0 {
+		return r.Sections
 	}
 
-	_, _ = fmt.Fprintln(out, "\n----------------------------------")
+	return []*reportSection{{Tasks: r.Tasks}}
+}
+
+// TimeSpent returns total time spent on report tasks.
+func (r *report) TimeSpent() time.Duration {
+	var res time.Duration
 
 	for _, t := range r.Tasks {
-		_, _ = fmt.Fprintf(out, "\n%s\n", t)
+		res += t.TimeSpent
 	}
 
-	_, _ = fmt.Fprintln(out, "\n----------------------------------")
+	return res
+}
+
+// ByStatus returns report tasks grouped by status.
+func (r *report) ByStatus() []*taskGroup {
+	return groupTasks("status", r.Tasks)
+}
+
+// ByType returns report tasks grouped by type.
+func (r *report) ByType() []*taskGroup {
+	return groupTasks("type", r.Tasks)
+}
+
+// Generate report.
+func (r *report) generate(out io.Writer) error {
+	return r.formatter.format(out, r)
 }
 
-func Report(tCli TrelloConnector, jCli JiraConnector, jiraURL string, reportHTML bool, reportWeekly bool) {
-	var tasks []*Task
+func Report(ctx context.Context, tCli TrelloConnector, jCli JiraConnector, jiraURL string, opts ReportOptions) {
+	var (
+		tasks    []*Task
+		sections []*reportSection
+	)
 
-	if reportWeekly {
-		tasks = WeeklyReportTasks(jCli)
-	} else {
-		tasks = trelloTasks(tCli, jiraURL)
+	// Trello report shows current state of cards, so it can't be built for another period.
+	if !opts.Weekly && opts.Range.isSet() {
+		log.Fatalf("report period can be set only for --weekly report based on jira tasks")
 	}
 
-	r := newReport(reportHTML, reportWeekly, tasks)
+	dateRange, err := resolveDateRange(ctx, jCli, opts.Range, time.Now())
+	if err != nil {
+		log.Fatalf("can't get report date range: %s", err)
+	}
 
-	r.generate(r.getOutputWriter())
+	switch {
+	case len(opts.Team) > 0 && opts.Weekly:
+		sections, err = teamJiraSections(ctx, jCli, opts.Team, dateRange, opts.JQL)
+	case len(opts.Team) > 0:
+		sections, err = teamTrelloSections(ctx, tCli, opts.Team, jiraURL)
+	case opts.Weekly:
+		tasks = WeeklyReportTasks(ctx, jCli, dateRange, opts.JQL)
+	default:
+		tasks = trelloTasks(ctx, tCli, jiraURL)
+	}
 
-	// Archive done tasks if HTML report is generated.
-	if reportHTML && !reportWeekly {
-		if err := tCli.ArchiveAllCardsInList(tCli.GetConfig().Lists.Done); err != nil {
-			log.Fatalf("can't archive done cards: %s", err)
+	if err != nil {
+		log.Fatalf("can't get team tasks: %s", err)
+	}
+
+	for _, section := range sections {
+		tasks = append(tasks, section.Tasks...)
+	}
+
+	r, err := newReport(opts, dateRange, tasks)
+	if err != nil {
+		log.Fatalf("can't create report: %s", err)
+	}
+
+	r.Sections = sections
+
+	ext := r.formatter.extension()
+	if opts.Output == OutputJSON {
+		ext = ""
+	}
+
+	if opts.ArchiveDone && (opts.Weekly || ext == "") {
+		log.Fatalf("done cards can be archived only when trello report is saved to file")
+	}
+
+	if ext == "" {
+		if err := r.generate(colorable.NewColorableStdout()); err != nil {
+			log.Fatalf("can't generate report: %s", err)
 		}
 
-		fmt.Println("Done cards archived")
+		return
+	}
+
+	fileName := "jira2trello-report-" + r.dateRange.fileSuffix() + "." + ext
+
+	if err := writeFileAtomically(fileName, r.generate); err != nil {
+		log.Fatalf("can't save report: %s", err)
 	}
-}
 
-// Determine destination writer
-// depends on html report flag.
-func (r *report) getOutputWriter() io.Writer {
-	year := strconv.Itoa(r.Year)
-	week := strconv.Itoa(r.WeekNumber)
+	fmt.Printf("Report saved to %s\n", fileName)
 
-	if r.HTMLReport {
-		//nolint:gomnd,nosnakecase
-		reportFile, err := os.OpenFile("jira2trello-report-"+year+"-"+week+".html",
-			os.O_CREATE|os.O_WRONLY, 0644)
+	// Archive done cards only when report is safely stored.
+	if opts.ArchiveDone {
+		manifest := "jira2trello-archived-" + r.dateRange.fileSuffix() + ".json"
 
-		if err != nil {
-			log.Fatalf("can't create report file: %s", err)
+		if err := archiveDoneCards(ctx, tCli, manifest); err != nil {
+			log.Fatalf("can't archive done cards: %s", err)
 		}
+	}
+}
+
+// writeFileAtomically writes content to temporary file, syncs it to disk and renames it to the file name,
+// so the file is either fully written or left untouched.
+func writeFileAtomically(fileName string, write func(io.Writer) error) error {
+	const filePermissions = 0644
 
-		fmt.Printf("Report saved to %s\n", reportFile.Name())
+	tmp, err := os.CreateTemp(filepath.Dir(fileName), "."+filepath.Base(fileName)+".*")
+	if err != nil {
+		return fmt.Errorf("can't create temporary file: %w", err)
+	}
 
-		return reportFile
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if err := write(tmp); err != nil {
+		return err
 	}
 
-	return colorable.NewColorableStdout()
+	if err := tmp.Sync(); err != nil {
+		return fmt.Errorf("can't sync file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("can't close file: %w", err)
+	}
+
+	if err := os.Chmod(tmp.Name(), filePermissions); err != nil {
+		return fmt.Errorf("can't set file permissions: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), fileName)
 }
 
-func trelloTasks(tCli TrelloConnector, jiraURL string) []*Task {
-	if err := tCli.Connect(); err != nil {
+func trelloTasks(ctx context.Context, tCli TrelloConnector, jiraURL string) []*Task {
+	if err := tCli.Connect(ctx); err != nil {
 		log.Fatalf("Can't connect to trello: %s", err)
 	}
 
-	tCards, err := tCli.GetUserJiraCards()
+	tCards, err := tCli.GetUserJiraCards(ctx)
 	if err != nil {
 		log.Fatalf("can't get trello cards: %s", err)
 	}
 
+	return cardsToTasks(tCli.GetConfig().Lists, tCards, jiraURL)
+}
+
+// cardsToTasks converts cards to tasks ordered by list: done, in progress, in review.
+func cardsToTasks(lists *trello.Lists, tCards []*trello.Card, jiraURL string) []*Task {
 	done := make([]*Task, 0)
 	inProgress := make([]*Task, 0)
 	inReview := make([]*Task, 0)
@@ -157,21 +308,21 @@ func trelloTasks(tCli TrelloConnector, jiraURL string) []*Task {
 
 	for _, tCard := range tCards {
 		switch {
-		case tCard.IsInAnyOfLists([]string{tCli.GetConfig().Lists.Done}):
+		case tCard.IsInAnyOfLists([]string{lists.Done}):
 			done = append(done, &Task{
 				Name:   strings.TrimPrefix(tCard.Name, tCard.Key+" | "),
 				Status: doneString,
 				Link:   jiraURL + "/browse/" + tCard.Key,
 				Key:    tCard.Key,
 			})
-		case tCard.IsInAnyOfLists([]string{tCli.GetConfig().Lists.Doing}):
+		case tCard.IsInAnyOfLists([]string{lists.Doing}):
 			inProgress = append(inProgress, &Task{
 				Name:   strings.TrimPrefix(tCard.Name, tCard.Key+" | "),
 				Status: doingString,
 				Link:   jiraURL + "/browse/" + tCard.Key,
 				Key:    tCard.Key,
 			})
-		case tCard.IsInAnyOfLists([]string{tCli.GetConfig().Lists.Review}):
+		case tCard.IsInAnyOfLists([]string{lists.Review}):
 			inReview = append(inReview, &Task{
 				Name:   strings.TrimPrefix(tCard.Name, tCard.Key+" | "),
 				Status: reviewString,
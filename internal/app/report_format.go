@@ -0,0 +1,51 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const defaultReportFormat = "text"
+
+// reportFormatter renders report in a specific format.
+// New formats are added by registering formatter in init function of its own file.
+type reportFormatter interface {
+	// extension returns report file extension, empty extension means that report is printed to stdout.
+	extension() string
+	format(out io.Writer, r *report) error
+}
+
+var reportFormatters = map[string]reportFormatter{}
+
+func registerReportFormatter(name string, f reportFormatter) {
+	if _, ok := reportFormatters[name]; ok {
+		panic("report formatter already registered: " + name)
+	}
+
+	reportFormatters[name] = f
+}
+
+func getReportFormatter(name string) (reportFormatter, error) {
+	f, ok := reportFormatters[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown report format `%s`, supported formats: %s",
+			name, strings.Join(ReportFormats(), ", "))
+	}
+
+	return f, nil
+}
+
+// ReportFormats returns sorted names of supported report formats.
+func ReportFormats() []string {
+	names := make([]string, 0, len(reportFormatters))
+
+	for name := range reportFormatters {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
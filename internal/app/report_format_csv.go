@@ -0,0 +1,47 @@
+package app
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+func init() {
+	registerReportFormatter("csv", csvFormatter{})
+}
+
+type csvFormatter struct{}
+
+func (csvFormatter) extension() string {
+	return "csv"
+}
+
+func (csvFormatter) format(out io.Writer, r *report) error {
+	w := csv.NewWriter(out)
+
+	header := []string{"Key", "Name", "Status", "Link"}
+	team := len(r.Sections) > 0
+
+	if team {
+		header = append(header, "Assignee")
+	}
+
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	for _, t := range r.Tasks {
+		record := []string{t.Key, t.Name, t.Status, t.Link}
+
+		if team {
+			record = append(record, t.Assignee)
+		}
+
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+
+	return w.Error()
+}
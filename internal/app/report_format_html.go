@@ -0,0 +1,24 @@
+package app
+
+import (
+	"html/template"
+	"io"
+)
+
+func init() {
+	registerReportFormatter("html", htmlFormatter{
+		tmpl: template.Must(template.New("report").Parse(htmlTemplate)),
+	})
+}
+
+type htmlFormatter struct {
+	tmpl *template.Template
+}
+
+func (htmlFormatter) extension() string {
+	return "html"
+}
+
+func (f htmlFormatter) format(out io.Writer, r *report) error {
+	return f.tmpl.Execute(out, r)
+}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+func init() {
+	registerReportFormatter("json", jsonFormatter{})
+}
+
+type jsonFormatter struct{}
+
+func (jsonFormatter) extension() string {
+	return "json"
+}
+
+func (jsonFormatter) format(out io.Writer, r *report) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(struct {
+		Year   int     `json:"year"`
+		Week   int     `json:"week"`
+		From   string  `json:"from"`
+		To     string  `json:"to"`
+		Sprint string  `json:"sprint,omitempty"`
+		Tasks  []*Task `json:"tasks"`
+	}{
+		Year:   r.Year,
+		Week:   r.WeekNumber,
+		From:   r.From.Format(dateLayout),
+		To:     r.To.Add(-time.Nanosecond).Format(dateLayout),
+		Sprint: r.Sprint,
+		Tasks:  r.Tasks,
+	})
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+func init() {
+	registerReportFormatter("markdown", markdownFormatter{})
+}
+
+type markdownFormatter struct{}
+
+func (markdownFormatter) extension() string {
+	return "md"
+}
+
+func (markdownFormatter) format(out io.Writer, r *report) error {
+	if _, err := fmt.Fprintf(out, "# %s\n\n", r.Title()); err != nil {
+		return err
+	}
+
+	for i, section := range r.sections() {
+		if section.Name != "" {
+			if i > 0 {
+				if _, err := fmt.Fprintln(out); err != nil {
+					return err
+				}
+			}
+
+			if _, err := fmt.Fprintf(out, "## %s\n\n", escapeMarkdown(section.Name)); err != nil {
+				return err
+			}
+		}
+
+		for _, t := range section.Tasks {
+			if _, err := fmt.Fprintf(out, "- [%s](%s) | %s - **%s**\n",
+				t.Key, t.Link, escapeMarkdown(t.Name), t.Status); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+var markdownReplacer = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`,
+)
+
+func escapeMarkdown(s string) string {
+	return markdownReplacer.Replace(s)
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+func init() {
+	registerReportFormatter("slack", slackFormatter{})
+}
+
+// slackFormatter renders report in Slack mrkdwn, so it's printed to stdout to be pasted into a message.
+type slackFormatter struct{}
+
+func (slackFormatter) extension() string {
+	return ""
+}
+
+func (slackFormatter) format(out io.Writer, r *report) error {
+	if _, err := fmt.Fprintf(out, "*%s*\n", r.Title()); err != nil {
+		return err
+	}
+
+	for _, section := range r.sections() {
+		if section.Name != "" {
+			if _, err := fmt.Fprintf(out, "\n*%s*\n", escapeSlack(section.Name)); err != nil {
+				return err
+			}
+		}
+
+		for _, t := range section.Tasks {
+			if _, err := fmt.Fprintf(out, "• <%s|%s> | %s - *%s*\n",
+				t.Link, t.Key, escapeSlack(t.Name), escapeSlack(t.Status)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// zeroWidthSpace splits formatting characters from text, since mrkdwn has no escaping for them.
+const zeroWidthSpace = "\u200b"
+
+var slackReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;",
+	"*", zeroWidthSpace+"*"+zeroWidthSpace, "_", zeroWidthSpace+"_"+zeroWidthSpace,
+	"~", zeroWidthSpace+"~"+zeroWidthSpace, "`", zeroWidthSpace+"`"+zeroWidthSpace)
+
+// escapeSlack escapes control characters and splits formatting ones, so text isn't rendered as formatted.
+func escapeSlack(s string) string {
+	return slackReplacer.Replace(s)
+}
@@ -0,0 +1,131 @@
+package app
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Template file suffixes, which are stripped to get report file extension,
+// e.g. `weekly.md.tmpl` produces `.md` report.
+var templateSuffixes = []string{".tmpl", ".tpl", ".gotmpl"}
+
+type executor interface {
+	Execute(wr io.Writer, data any) error
+}
+
+// templateFormatter renders report with user supplied template.
+// Templates with html extension are executed with html/template, others with text/template.
+type templateFormatter struct {
+	tmpl executor
+	ext  string
+}
+
+func newTemplateFormatter(path string) (reportFormatter, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("can't read report template: %w", err)
+	}
+
+	name := filepath.Base(path)
+	for _, suffix := range templateSuffixes {
+		name = strings.TrimSuffix(name, suffix)
+	}
+
+	f := &templateFormatter{
+		ext: strings.TrimPrefix(filepath.Ext(name), "."),
+	}
+
+	switch strings.ToLower(f.ext) {
+	case "html", "htm":
+		f.tmpl, err = htmltemplate.New(name).Funcs(reportTemplateFuncs).Parse(string(content))
+	default:
+		f.tmpl, err = template.New(name).Funcs(reportTemplateFuncs).Parse(string(content))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("can't parse report template: %w", err)
+	}
+
+	return f, nil
+}
+
+func (f *templateFormatter) extension() string {
+	return f.ext
+}
+
+func (f *templateFormatter) format(out io.Writer, r *report) error {
+	return f.tmpl.Execute(out, r)
+}
+
+var reportTemplateFuncs = map[string]any{
+	"groupBy":          groupTasks,
+	"sumHours":         sumHours,
+	"humanizeDuration": humanizeDuration,
+}
+
+type taskGroup struct {
+	Name  string
+	Tasks []*Task
+}
+
+// groupTasks groups tasks by `status`, `type` or `parent` field keeping order of first occurrence.
+func groupTasks(field string, tasks []*Task) []*taskGroup {
+	groups := make([]*taskGroup, 0)
+	index := map[string]*taskGroup{}
+
+	for _, t := range tasks {
+		var name string
+
+		switch strings.ToLower(field) {
+		case "status":
+			name = t.Status
+		case "type":
+			name = t.Type
+		case "parent":
+			name = t.ParentKey
+		}
+
+		g, ok := index[name]
+		if !ok {
+			g = &taskGroup{Name: name}
+			index[name] = g
+			groups = append(groups, g)
+		}
+
+		g.Tasks = append(g.Tasks, t)
+	}
+
+	return groups
+}
+
+func sumHours(tasks []*Task) float64 {
+	var res time.Duration
+
+	for _, t := range tasks {
+		res += t.TimeSpent
+	}
+
+	return res.Hours()
+}
+
+// humanizeDuration formats duration in hours and minutes, e.g. `12h 30m`.
+func humanizeDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) - hours*60
+
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	}
+
+	return fmt.Sprintf("%dh %dm", hours, minutes)
+}
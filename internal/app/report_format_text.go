@@ -0,0 +1,40 @@
+package app
+
+import (
+	"fmt"
+	"io"
+)
+
+func init() {
+	registerReportFormatter(defaultReportFormat, textFormatter{})
+}
+
+type textFormatter struct{}
+
+func (textFormatter) extension() string {
+	return ""
+}
+
+func (textFormatter) format(out io.Writer, r *report) error {
+	if _, err := fmt.Fprintln(out, "\n----------------------------------"); err != nil {
+		return err
+	}
+
+	for _, section := range r.sections() {
+		if section.Name != "" {
+			if _, err := fmt.Fprintf(out, "\n=== %s ===\n", section.Name); err != nil {
+				return err
+			}
+		}
+
+		for _, t := range section.Tasks {
+			if _, err := fmt.Fprintf(out, "\n%s\n", t); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := fmt.Fprintln(out, "\n----------------------------------")
+
+	return err
+}
@@ -1,10 +1,17 @@
 package app
 
-const htmlTemplate = `<!DOCTYPE html>
+const htmlTemplate = `{{- define "tasks" }}
+	<ul>
+{{- range $task := . }}
+	<li><a href={{ $task.Link }}>{{ $task.Key }}</a> | {{ $task.Name }} - <strong>{{ $task.Status }}</strong></li>
+{{- end }}
+	</ul>
+{{- end -}}
+<!DOCTYPE html>
 <html lang="en">
 <head>
 	<meta charset="UTF-8">
-	<title>Report {{ .Year }} week {{ .WeekNumber }}</title>
+	<title>{{ .Title }}</title>
 	<style>
 		* {	
 			font-family: sans-serif;
@@ -13,12 +20,13 @@ const htmlTemplate = `<!DOCTYPE html>
 </head>
 
 <body>
-	<h1>Report {{ .Year }} week {{ .WeekNumber }}</h1>
-	<ul>
-{{- range $task := .Tasks }}
-	<li><a href={{ $task.Link }}>{{ $task.Key }}</a> | {{ $task.Name }} - <strong>{{ $task.Status }}</strong></li>
+	<h1>{{ .Title }}</h1>
+{{- range $section := .Sections }}
+	<h2>{{ $section.Name }}</h2>
+{{- template "tasks" $section.Tasks }}
+{{- else }}
+{{- template "tasks" .Tasks }}
 {{- end }}
-	</ul>
 </body>
 </html>
 `
@@ -2,9 +2,12 @@ package app
 
 import (
 	"bytes"
+	"context"
 	"github.com/Brialius/jira2trello/internal/trello"
 	"github.com/mattn/go-colorable"
+	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func Test_printReport(t *testing.T) {
@@ -15,7 +18,7 @@ func Test_printReport(t *testing.T) {
 
 	type args struct {
 		tCli   TrelloConnector
-		html   bool
+		format string
 		weekly bool
 	}
 	tests := []struct {
@@ -26,7 +29,8 @@ func Test_printReport(t *testing.T) {
 		{
 			name: "valid",
 			args: args{
-				tCli: tCli,
+				tCli:   tCli,
+				format: "text",
 			},
 			wantOut: `
 ----------------------------------
@@ -94,8 +98,8 @@ https://jira-site/browse/JIRA1-1324
 		{
 			name: "valid html",
 			args: args{
-				tCli: tCli,
-				html: true,
+				tCli:   tCli,
+				format: "html",
 			},
 			wantOut: `<!DOCTYPE html>
 <html lang="en">
@@ -140,14 +144,16 @@ https://jira-site/browse/JIRA1-1324
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			out := &bytes.Buffer{}
-			tasks := trelloTasks(tt.args.tCli, "https://jira-site")
-			r := newReport(tt.args.html, tt.args.weekly, tasks)
+			tasks := trelloTasks(context.Background(), tt.args.tCli, "https://jira-site")
+			r, err := newReport(ReportOptions{Format: tt.args.format, Weekly: tt.args.weekly},
+				defaultDateRange(time.Now()), tasks)
+			require.NoError(t, err)
 
 			// Set date related fields to fixed values for testing
 			r.Year = 2000
 			r.WeekNumber = 1
 
-			r.generate(colorable.NewNonColorable(out))
+			require.NoError(t, r.generate(colorable.NewNonColorable(out)))
 
 			if gotOut := out.String(); gotOut != tt.wantOut {
 				t.Errorf("printReport() = %v, want %v", gotOut, tt.wantOut)
@@ -155,3 +161,159 @@ https://jira-site/browse/JIRA1-1324
 		})
 	}
 }
+
+func Test_reportFormatters(t *testing.T) {
+	tasks := []*Task{
+		{Name: "Test task 1289", Status: doneString, Link: "https://jira-site/browse/JIRA1-1289", Key: "JIRA1-1289"},
+		{Name: "Fix *bold* <tag>", Status: doingString, Link: "https://jira-site/browse/JIRA1-1130", Key: "JIRA1-1130"},
+	}
+
+	tests := []struct {
+		name    string
+		format  string
+		wantOut string
+		wantErr bool
+	}{
+		{
+			name:   "markdown",
+			format: "markdown",
+			wantOut: `# Report 2000 week 1
+
+- [JIRA1-1289](https://jira-site/browse/JIRA1-1289) | Test task 1289 - **Done**
+- [JIRA1-1130](https://jira-site/browse/JIRA1-1130) | Fix \*bold\* <tag> - **In progress**
+`,
+		},
+		{
+			name:   "json",
+			format: "json",
+			wantOut: `{
+  "year": 2000,
+  "week": 1,
+  "from": "2000-01-03",
+  "to": "2000-01-09",
+  "tasks": [
+    {
+      "name": "Test task 1289",
+      "status": "Done",
+      "link": "https://jira-site/browse/JIRA1-1289",
+      "key": "JIRA1-1289"
+    },
+    {
+      "name": "Fix *bold* \u003ctag\u003e",
+      "status": "In progress",
+      "link": "https://jira-site/browse/JIRA1-1130",
+      "key": "JIRA1-1130"
+    }
+  ]
+}
+`,
+		},
+		{
+			name:   "csv",
+			format: "csv",
+			wantOut: `Key,Name,Status,Link
+JIRA1-1289,Test task 1289,Done,https://jira-site/browse/JIRA1-1289
+JIRA1-1130,Fix *bold* <tag>,In progress,https://jira-site/browse/JIRA1-1130
+`,
+		},
+		{
+			name:   "slack",
+			format: "slack",
+			wantOut: "*Report 2000 week 1*\n" +
+				"• <https://jira-site/browse/JIRA1-1289|JIRA1-1289> | Test task 1289 - *Done*\n" +
+				"• <https://jira-site/browse/JIRA1-1130|JIRA1-1130> | Fix \u200b*\u200bbold\u200b*\u200b &lt;tag&gt; - " +
+				"*In progress*\n",
+		},
+		{
+			name:    "unknown",
+			format:  "pdf",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := newReport(ReportOptions{Format: tt.format}, defaultDateRange(time.Now()), tasks)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			r.Year = 2000
+			r.WeekNumber = 1
+			r.From = time.Date(2000, 1, 3, 0, 0, 0, 0, time.UTC)
+			r.To = time.Date(2000, 1, 10, 0, 0, 0, 0, time.UTC)
+
+			out := &bytes.Buffer{}
+			require.NoError(t, r.generate(out))
+			require.Equal(t, tt.wantOut, out.String())
+		})
+	}
+}
+
+func Test_escapeSlack(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Plain task", want: "Plain task"},
+		{name: "a & <b>", want: "a &amp; &lt;b&gt;"},
+		{name: "fix *nix_path*", want: "fix \u200b*\u200bnix\u200b_\u200bpath\u200b*\u200b"},
+		{name: "~old~ `code`", want: "\u200b~\u200bold\u200b~\u200b \u200b`\u200bcode\u200b`\u200b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, escapeSlack(tt.name))
+		})
+	}
+}
+
+func Test_newReportJSONOutput(t *testing.T) {
+	r, err := newReport(ReportOptions{Format: "html", Template: "report.tmpl", Output: OutputJSON},
+		defaultDateRange(time.Now()), []*Task{{Name: "Task 1", Status: doneString, Key: "JIRA1-1"}})
+	require.NoError(t, err)
+	require.Equal(t, jsonFormatter{}, r.formatter)
+}
+
+func Test_templateFormatter(t *testing.T) {
+	tasks := []*Task{
+		{Name: "Task 1", Status: "Closed", Key: "JIRA1-1", Type: "Bug", TimeSpent: 90 * time.Minute},
+		{Name: "Task 2", Status: "In Progress", Key: "JIRA1-2", Type: "Sub-task", TimeSpent: 2 * time.Hour,
+			ParentKey: "JIRA1-10"},
+		{Name: "Task 3", Status: "Closed", Key: "JIRA1-3", Type: "Sub-task", TimeSpent: 30 * time.Minute},
+	}
+
+	r, err := newReport(ReportOptions{Template: "testdata/test_report.md.tmpl"}, defaultDateRange(time.Now()), tasks)
+	require.NoError(t, err)
+	require.Equal(t, "md", r.formatter.extension())
+
+	r.Year = 2000
+	r.WeekNumber = 1
+	r.From = time.Date(2000, 1, 3, 0, 0, 0, 0, time.UTC)
+	r.To = time.Date(2000, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, r.generate(out))
+	require.Equal(t, `# Week 1, 2000 (2000-01-03 - 2000-01-10)
+
+## Closed (2.0h)
+- JIRA1-1 Task 1
+- JIRA1-3 Task 3
+
+## In Progress (2.0h)
+- JIRA1-2 Task 2 (parent JIRA1-10)
+
+Bug: 1
+Sub-task: 2
+Total: 4h
+`, out.String())
+}
+
+func Test_humanizeDuration(t *testing.T) {
+	require.Equal(t, "0m", humanizeDuration(0))
+	require.Equal(t, "45m", humanizeDuration(45*time.Minute))
+	require.Equal(t, "3h", humanizeDuration(3*time.Hour))
+	require.Equal(t, "26h 30m", humanizeDuration(26*time.Hour+30*time.Minute))
+}
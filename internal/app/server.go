@@ -0,0 +1,330 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Brialius/jira2trello/internal/metrics"
+	"log"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	readHeaderTimeout = 10 * time.Second
+	shutdownTimeout   = 10 * time.Second
+)
+
+// ServeOptions are settings of serve command.
+type ServeOptions struct {
+	Addr string
+	// Interval between syncs, sync runs only on `POST /sync` if it's zero.
+	Interval time.Duration
+	JiraURL  string
+	Webhooks WebhookConfig
+}
+
+// syncRun is a sync shown by `/status`, Key is set for sync of the issue triggered by webhook.
+type syncRun struct {
+	Key      string      `json:"key,omitempty"`
+	Started  time.Time   `json:"started"`
+	Finished time.Time   `json:"finished"`
+	Result   *syncResult `json:"result"`
+}
+
+type serverStatus struct {
+	Running  bool     `json:"running"`
+	LastSync *syncRun `json:"lastSync"`
+}
+
+// server serves live HTML report, status of the last sync and runs sync on demand.
+type server struct {
+	sync     *SyncService
+	jiraURL  string
+	webhooks WebhookConfig
+	metrics  *syncMetrics
+	// mu serializes syncs and reports, since they share clients and sync state.
+	mu sync.Mutex
+	// stateMu guards running, pending and last.
+	stateMu sync.Mutex
+	running bool
+	// pending are keys of issues waiting for sync triggered by webhooks.
+	pending map[string]bool
+	last    *syncRun
+}
+
+func newServer(s *SyncService, opts ServeOptions) *server {
+	return &server{
+		sync:     s,
+		jiraURL:  opts.JiraURL,
+		webhooks: opts.Webhooks,
+		metrics:  defaultSyncMetrics,
+		pending:  map[string]bool{},
+	}
+}
+
+// Serve runs HTTP server until context is done, cards are synced every interval if it's set.
+// Webhooks are registered if their URL is set, then polling by interval is a fallback for missed events.
+func (s *SyncService) Serve(ctx context.Context, opts ServeOptions) {
+	// Clients are connected once and shared by syncs, reports and webhooks.
+	if err := s.connect(ctx); err != nil {
+		log.Fatalf("Can't connect: %s", err)
+	}
+
+	srv := newServer(s, opts)
+	httpServer := &http.Server{
+		Addr:              opts.Addr,
+		Handler:           srv.handler(ctx),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+
+	ln, err := net.Listen("tcp", opts.Addr)
+	if err != nil {
+		log.Fatalf("Can't listen: %s", err)
+	}
+
+	if opts.Interval > 0 {
+		go srv.watch(ctx, opts.Interval)
+	}
+
+	// Webhooks are registered when server is listening, since Trello checks callback URL.
+	if opts.Webhooks.URL != "" {
+		go srv.registerWebhooks(ctx)
+	}
+
+	slog.Info("Serving", "addr", ln.Addr().String())
+
+	if err := httpServer.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("Can't serve: %s", err)
+	}
+}
+
+func (srv *server) handler(ctx context.Context) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		if !allowMethod(w, r, http.MethodGet) {
+			return
+		}
+
+		srv.serveReport(w, r)
+	})
+
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		if !allowMethod(w, r, http.MethodGet) {
+			return
+		}
+
+		srv.stateMu.Lock()
+		status := serverStatus{Running: srv.running, LastSync: srv.last}
+		srv.stateMu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = writeJSON(w, status)
+	})
+
+	mux.HandleFunc("/sync", func(w http.ResponseWriter, r *http.Request) {
+		if !allowMethod(w, r, http.MethodPost) {
+			return
+		}
+
+		// Sync outlives the request, so it runs with server context.
+		if !srv.start(ctx) {
+			http.Error(w, "sync is already running", http.StatusConflict)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = fmt.Fprintln(w, "sync started")
+	})
+
+	mux.HandleFunc(trelloWebhookPath, func(w http.ResponseWriter, r *http.Request) {
+		srv.handleTrelloWebhook(ctx, w, r)
+	})
+
+	mux.HandleFunc(jiraWebhookPath, func(w http.ResponseWriter, r *http.Request) {
+		srv.handleJiraWebhook(ctx, w, r)
+	})
+
+	mux.Handle("/metrics", metrics.Handler(metrics.Default))
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintln(w, "ok")
+	})
+
+	return mux
+}
+
+// allowMethod responds with 405 status and returns false if request method isn't the method.
+func allowMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method == method || method == http.MethodGet && r.Method == http.MethodHead {
+		return true
+	}
+
+	w.Header().Set("Allow", method)
+	http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+	return false
+}
+
+// serveReport responds with HTML report of user cards for the last 7 days.
+func (srv *server) serveReport(w http.ResponseWriter, r *http.Request) {
+	out := &bytes.Buffer{}
+
+	if err := srv.report(r.Context(), out); err != nil {
+		slog.Error("Can't generate report", "error", err)
+		http.Error(w, "can't generate report", http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = out.WriteTo(w)
+}
+
+func (srv *server) report(ctx context.Context, out *bytes.Buffer) error {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	if err := srv.sync.connect(ctx); err != nil {
+		return err
+	}
+
+	tCli := srv.sync.tCli
+
+	tCards, err := tCli.GetUserJiraCards(ctx)
+	if err != nil {
+		return fmt.Errorf("can't get trello cards: %w", err)
+	}
+
+	r, err := newReport(ReportOptions{Format: "html"}, defaultDateRange(time.Now()),
+		cardsToTasks(tCli.GetConfig().Lists, tCards, srv.jiraURL))
+	if err != nil {
+		return fmt.Errorf("can't create report: %w", err)
+	}
+
+	return r.generate(out)
+}
+
+// watch starts sync immediately and then every interval until context is done.
+func (srv *server) watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if !srv.start(ctx) {
+			slog.Info("Sync is still running, skipping")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// start runs sync in background, it returns false if sync is already running.
+func (srv *server) start(ctx context.Context) bool {
+	srv.stateMu.Lock()
+	defer srv.stateMu.Unlock()
+
+	if srv.running {
+		return false
+	}
+
+	srv.running = true
+
+	go srv.runSync(ctx)
+
+	return true
+}
+
+func (srv *server) runSync(ctx context.Context) {
+	srv.mu.Lock()
+	run := srv.run(ctx, "")
+	srv.mu.Unlock()
+
+	srv.stateMu.Lock()
+	defer srv.stateMu.Unlock()
+
+	srv.running = false
+	srv.last = run
+}
+
+// startIssue syncs card of the issue in background, the issue waiting for sync isn't queued twice.
+func (srv *server) startIssue(ctx context.Context, key string) {
+	srv.stateMu.Lock()
+	defer srv.stateMu.Unlock()
+
+	if srv.pending[key] {
+		return
+	}
+
+	srv.pending[key] = true
+
+	go func() {
+		srv.mu.Lock()
+
+		// Events received from now on need another sync, since this one may miss their changes.
+		srv.stateMu.Lock()
+		delete(srv.pending, key)
+		srv.stateMu.Unlock()
+
+		run := srv.run(ctx, key)
+		srv.mu.Unlock()
+
+		srv.stateMu.Lock()
+		defer srv.stateMu.Unlock()
+
+		srv.last = run
+	}()
+}
+
+// run syncs cards, only card of the issue is synced if key is set. It should be called with mu locked.
+func (srv *server) run(ctx context.Context, key string) *syncRun {
+	run := &syncRun{Key: key, Started: time.Now(), Result: newSyncResult()}
+	srv.sync.result = run.Result
+
+	var err error
+
+	if key == "" {
+		err = srv.sync.sync(ctx, false)
+	} else {
+		err = srv.sync.syncIssue(ctx, key)
+	}
+
+	if err != nil {
+		slog.Error("Sync failed", "key", key, "error", err)
+	} else {
+		slog.Info("Sync completed", "key", key)
+	}
+
+	run.Result.addError(err)
+	run.Result.sort()
+	run.Finished = time.Now()
+
+	srv.metrics.observe(run)
+
+	return run
+}
@@ -0,0 +1,155 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func getTestServer(t *testing.T) (*server, *JiraConnectorMock, *TrelloConnectorMock) {
+	t.Helper()
+
+	var jTasks = map[string]*jira.Task{}
+	mustLoadJSONFile(t, "testdata/test_jira_tasks.json", &jTasks)
+
+	tCards := make([]*trello.Card, 0)
+	mustLoadJSONFile(t, "testdata/test_trello_cards.json", &tCards)
+
+	jCli := GetJiraMockedCli(jTasks)
+	tCli := GetTrelloMockedCli(tCards)
+
+	return newServer(&SyncService{jCli: jCli, tCli: tCli}, ServeOptions{
+		JiraURL:  "https://jira-site",
+		Webhooks: WebhookConfig{URL: "https://j2t.example.com/", Secret: "jira-secret"},
+	}), jCli, tCli
+}
+
+func serveRequest(t *testing.T, h http.Handler, method, path string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+
+	return w
+}
+
+func getServerStatus(t *testing.T, h http.Handler) serverStatus {
+	t.Helper()
+
+	w := serveRequest(t, h, http.MethodGet, "/status")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var status serverStatus
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+
+	return status
+}
+
+func TestServer_handler(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		wantCode int
+		wantBody string
+	}{
+		{name: "health", method: http.MethodGet, path: "/healthz", wantCode: http.StatusOK, wantBody: "ok\n"},
+		{name: "unknown path", method: http.MethodGet, path: "/unknown", wantCode: http.StatusNotFound},
+		{name: "report by post", method: http.MethodPost, path: "/", wantCode: http.StatusMethodNotAllowed},
+		{name: "sync by get", method: http.MethodGet, path: "/sync", wantCode: http.StatusMethodNotAllowed},
+		{name: "status by post", method: http.MethodPost, path: "/status", wantCode: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _, _ := getTestServer(t)
+
+			w := serveRequest(t, srv.handler(context.Background()), tt.method, tt.path)
+			require.Equal(t, tt.wantCode, w.Code)
+
+			if tt.wantBody != "" {
+				require.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestServer_report(t *testing.T) {
+	srv, _, tCli := getTestServer(t)
+	h := srv.handler(context.Background())
+
+	w := serveRequest(t, h, http.MethodGet, "/")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	require.Contains(t, w.Body.String(), "<html")
+	require.Contains(t, w.Body.String(), "https://jira-site/browse/JIRA1-1324")
+	require.Contains(t, w.Body.String(), "Test task 1324")
+
+	tCli.GetUserJiraCardsFunc = func(ctx context.Context) ([]*trello.Card, error) {
+		return nil, errors.New("trello is down")
+	}
+
+	w = serveRequest(t, h, http.MethodGet, "/")
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.NotContains(t, w.Body.String(), "trello is down")
+
+	// Client is connected once, so its HTTP client and board are reused by next requests.
+	require.Len(t, tCli.ConnectCalls(), 1)
+}
+
+func TestServer_sync(t *testing.T) {
+	srv, jCli, tCli := getTestServer(t)
+	h := srv.handler(context.Background())
+
+	require.Equal(t, serverStatus{}, getServerStatus(t, h))
+
+	// Sync waits for the report, so it can't finish before the second request.
+	srv.mu.Lock()
+
+	w := serveRequest(t, h, http.MethodPost, "/sync")
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	w = serveRequest(t, h, http.MethodPost, "/sync")
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.True(t, getServerStatus(t, h).Running)
+
+	srv.mu.Unlock()
+
+	require.Eventually(t, func() bool {
+		return !getServerStatus(t, h).Running
+	}, time.Second, 10*time.Millisecond)
+
+	status := getServerStatus(t, h)
+	require.NotNil(t, status.LastSync)
+	require.False(t, status.LastSync.Finished.Before(status.LastSync.Started))
+	require.Equal(t, []*cardChange{{Key: "JIRA1-1194", List: "Doing"}}, status.LastSync.Result.Created)
+	require.Len(t, status.LastSync.Result.Moved, 3)
+	require.Empty(t, status.LastSync.Result.Errors)
+	require.Len(t, tCli.CreateCardCalls(), 1)
+
+	jCli.GetTasksFunc = func(ctx context.Context, jql string) (map[string]*jira.Task, error) {
+		return nil, errors.New("jira is down")
+	}
+
+	w = serveRequest(t, h, http.MethodPost, "/sync")
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	require.Eventually(t, func() bool {
+		status := getServerStatus(t, h)
+
+		return !status.Running && len(status.LastSync.Result.Errors) > 0
+	}, time.Second, 10*time.Millisecond)
+
+	status = getServerStatus(t, h)
+	require.Len(t, status.LastSync.Result.Errors, 1)
+	require.True(t, strings.HasSuffix(status.LastSync.Result.Errors[0].Error, "jira is down"))
+}
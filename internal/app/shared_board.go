@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"log/slog"
+	"strings"
+)
+
+// getSharedTrelloCards returns Jira cards of shared board split to cards owned by current user and
+// cards owned by other users.
+func getSharedTrelloCards(ctx context.Context,
+	tCli TrelloConnector) (map[string]*trello.Card, map[string]*trello.Card, error) {
+	slog.Info("Getting Trello cards")
+
+	owned := map[string]*trello.Card{}
+	foreign := map[string]*trello.Card{}
+
+	cards, err := tCli.GetJiraCards(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("can't get board cards: %w", err)
+	}
+
+	for _, card := range cards {
+		if card.Key == "" {
+			continue
+		}
+
+		if card.OwnedBy(tCli.GetConfig().UserID) {
+			owned[card.Key] = card
+		} else {
+			foreign[card.Key] = card
+		}
+	}
+
+	slog.Info("Trello cards found", "count", len(owned), "foreign", len(foreign))
+
+	return owned, foreign, nil
+}
+
+// prepareJiraSelf resolves current Jira user once, configured user is empty with token auth
+// and differs from account ID of assignee on Jira cloud.
+func (s *SyncService) prepareJiraSelf(ctx context.Context) error {
+	if s.jiraSelf != nil {
+		return nil
+	}
+
+	self, err := s.jCli.GetSelf(ctx)
+	if err != nil {
+		return fmt.Errorf("can't get current jira user: %w", err)
+	}
+
+	s.jiraSelf = self
+
+	return nil
+}
+
+// isCurrentJiraUser returns true if Jira user is the current user, it's compared by username on Jira server,
+// by account ID on Jira cloud or by email.
+func (s *SyncService) isCurrentJiraUser(user *jira.User) bool {
+	if user == nil || s.jiraSelf == nil {
+		return false
+	}
+
+	self := s.jiraSelf
+
+	return user.Name != "" && strings.EqualFold(user.Name, self.Name) ||
+		user.Email != "" && strings.EqualFold(user.Email, self.Email)
+}
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"context"
+	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"github.com/stretchr/testify/require"
+	"sort"
+	"testing"
+)
+
+func TestSyncService_sharedBoard(t *testing.T) {
+	const (
+		me     = "111111111111111111111111"
+		bob    = "333333333333333333333333"
+		todo   = "12345678909876543219d1c9"
+		done   = "12345678909876543219d1cf"
+		jiraID = "121212121212121212121fa4"
+		taskID = "121212121212121212121795"
+	)
+
+	newTask := func(key, assignee string) *jira.Task {
+		return &jira.Task{
+			Key: key, Summary: "Task " + key, Status: "ToDo", Type: "Sub-task",
+			Link: "https://jira-site/browse/" + key, Assignee: &jira.User{Name: assignee},
+		}
+	}
+
+	newCard := func(id, key, members string, marker *trello.Marker) *trello.Card {
+		return &trello.Card{
+			ID: id, Key: key, Name: key + " | Task " + key, ListID: todo, Desc: "Task " + key,
+			IDLabels: &[]string{jiraID, taskID}, IDMembers: members, Marker: marker,
+		}
+	}
+
+	tCli := GetTrelloMockedCli(nil)
+	cfg := tCli.GetConfig()
+	cfg.Shared = true
+	tCli.GetConfigFunc = func() *trello.Config {
+		return cfg
+	}
+	tCli.GetJiraCardsFunc = func(ctx context.Context) ([]*trello.Card, error) {
+		return []*trello.Card{
+			newCard("c1", "K-1", me, nil),
+			newCard("c2", "K-2", me, &trello.Marker{Key: "K-2", Link: "https://jira-site/browse/K-2", Owner: bob}),
+			newCard("c3", "K-3", bob, &trello.Marker{Key: "K-3", Link: "https://jira-site/browse/K-3", Owner: bob}),
+			newCard("c4", "K-4", bob, &trello.Marker{Key: "K-4", Link: "https://jira-site/browse/K-4", Owner: bob}),
+			newCard("c5", "K-5", bob, &trello.Marker{Key: "K-5", Link: "https://jira-site/browse/K-5", Owner: me}),
+		}, nil
+	}
+	tCli.UpdateCardDescFunc = func(ctx context.Context, cardID, desc string) error {
+		return nil
+	}
+
+	// Current user is resolved by API, configured user is empty with token auth.
+	jCli := GetJiraMockedCli(nil)
+	jCli.GetSelfFunc = func(ctx context.Context) (*jira.User, error) {
+		return &jira.User{Name: "5b10ac8d82e05b22cc7d4ef5", Email: "me@example.com"}, nil
+	}
+
+	k3 := newTask("K-3", "")
+	k3.Assignee = &jira.User{Email: "ME@example.com"}
+
+	s := &SyncService{
+		jCli: jCli,
+		tCli: tCli,
+		jTasks: map[string]*jira.Task{
+			"K-1": newTask("K-1", "5b10ac8d82e05b22cc7d4ef5"),
+			"K-2": newTask("K-2", "bob"),
+			"K-3": k3,
+		},
+	}
+
+	require.NoError(t, s.loadCards(context.Background()))
+	require.NoError(t, s.loadCards(context.Background()))
+	require.Len(t, jCli.GetSelfCalls(), 1)
+	require.Len(t, s.tCards, 2)
+	require.Len(t, s.foreign, 3)
+
+	require.NoError(t, s.syncTasks(context.Background()))
+	require.NoError(t, s.syncCompletedTasks(context.Background()))
+
+	descCalls := stringCalls(tCli.UpdateCardDescCalls())
+	sort.Sort(descCalls)
+
+	require.Equal(t, calls{
+		{"c1", "Task K-1\n\n[jira2trello]: <https://jira-site/browse/K-1> \"key=K-1 owner=" + me + "\""},
+		{"c3", "Task K-3\n\n[jira2trello]: <https://jira-site/browse/K-3> \"key=K-3 owner=" + me + "\""},
+	}, descCalls)
+
+	require.Equal(t, calls{{"c5", done}}, stringCalls(tCli.MoveCardToListCalls()))
+	require.Empty(t, tCli.CreateCardCalls())
+	require.Empty(t, tCli.UpdateCardLabelsCalls())
+}
@@ -0,0 +1,241 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/theme"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"github.com/mattn/go-colorable"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+// Card states shown by status command.
+const (
+	cardInSync    = "in sync"
+	cardOutOfSync = "out of sync"
+	cardMissing   = "missing"
+	cardOrphaned  = "orphaned"
+	cardForeign   = "foreign"
+)
+
+var cardStateColors = map[string]string{
+	cardInSync:    theme.Green,
+	cardOutOfSync: theme.Yellow,
+	cardMissing:   theme.Red,
+	cardOrphaned:  theme.Purple,
+	cardForeign:   theme.Blue,
+}
+
+// cardStatus is a state of Jira task card, List is the list card is in and WantList is the list sync moves it to.
+// Labels, which sync adds to the card, are in MissingLabels, labels it removes are in ExtraLabels.
+type cardStatus struct {
+	Key           string   `json:"key"`
+	JiraStatus    string   `json:"jiraStatus,omitempty"`
+	List          string   `json:"list,omitempty"`
+	WantList      string   `json:"wantList,omitempty"`
+	MissingLabels []string `json:"missingLabels,omitempty"`
+	ExtraLabels   []string `json:"extraLabels,omitempty"`
+	State         string   `json:"state"`
+}
+
+// labelDrift returns label changes as `+Added -Removed`.
+func (c *cardStatus) labelDrift() string {
+	res := make([]string, 0, len(c.MissingLabels)+len(c.ExtraLabels))
+
+	for _, label := range c.MissingLabels {
+		res = append(res, "+"+label)
+	}
+
+	for _, label := range c.ExtraLabels {
+		res = append(res, "-"+label)
+	}
+
+	return strings.Join(res, " ")
+}
+
+// Status prints how cards differ from Jira tasks, nothing is changed on both sides.
+func (s *SyncService) Status(ctx context.Context, output string) {
+	statuses, err := s.status(ctx)
+	if err != nil {
+		log.Fatalf("Can't get sync status: %s", err)
+	}
+
+	if output == OutputJSON {
+		if err := writeJSON(os.Stdout, statuses); err != nil {
+			log.Fatalf("Can't write sync status: %s", err)
+		}
+
+		return
+	}
+
+	printCardStatuses(colorable.NewColorableStdout(), s.theme, statuses)
+}
+
+func (s *SyncService) status(ctx context.Context) ([]*cardStatus, error) {
+	if err := s.connect(ctx); err != nil {
+		return nil, err
+	}
+
+	query, err := s.jql.syncJQL(time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("can't get jira query: %w", err)
+	}
+
+	slog.Info("Getting Jira tasks")
+
+	if s.jTasks, err = s.jCli.GetTasks(ctx, query); err != nil {
+		return nil, fmt.Errorf("can't get jira tasks: %w", err)
+	}
+
+	if err := s.prepareEpics(ctx); err != nil {
+		return nil, fmt.Errorf("can't prepare epics: %w", err)
+	}
+
+	labels, err := s.tCli.GetLabels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can't get labels: %w", err)
+	}
+
+	labelNames := map[string]string{}
+	for name, label := range labels {
+		labelNames[label.ID] = name
+	}
+
+	if err := s.loadCards(ctx); err != nil {
+		return nil, err
+	}
+
+	res := make([]*cardStatus, 0, len(s.jTasks))
+
+	for _, key := range sortedKeys(s.jTasks) {
+		res = append(res, s.taskStatus(s.jTasks[key], labelNames))
+	}
+
+	lists := s.tCli.GetConfig().Lists
+
+	for _, key := range sortedKeys(s.tCards) {
+		if tCard := s.tCards[key]; s.jTasks[key] == nil && tCard.ListID != lists.Done {
+			res = append(res, &cardStatus{
+				Key:      key,
+				List:     listName(tCard.ListID, lists),
+				WantList: listName(lists.Done, lists),
+				State:    cardOrphaned,
+			})
+		}
+	}
+
+	return res, nil
+}
+
+// taskStatus compares card of Jira task with the card sync would make, epic labels aren't created.
+func (s *SyncService) taskStatus(jTask *jira.Task, labelNames map[string]string) *cardStatus {
+	lists := s.tCli.GetConfig().Lists
+	listID, labels := s.taskListAndLabels(jTask)
+
+	res := &cardStatus{
+		Key:        jTask.Key,
+		JiraStatus: jTask.Status,
+		WantList:   listName(listID, lists),
+	}
+
+	wantLabels := map[string]string{}
+	for _, id := range labels {
+		wantLabels[id] = labelName(id, labelNames)
+	}
+
+	// Label of epic is empty if it isn't created yet, so it's reported as missing.
+	if s.epicLabels != nil && jTask.EpicKey != "" {
+		name := epicName(jTask)
+		wantLabels[s.epicLabels[name]] = name
+	}
+
+	tCard, ok := s.tCards[jTask.Key]
+	if !ok {
+		if tCard, ok = s.foreign[jTask.Key]; ok && !s.isCurrentJiraUser(jTask.Assignee) {
+			res.List = listName(tCard.ListID, lists)
+			res.State = cardForeign
+
+			return res
+		}
+	}
+
+	if !ok {
+		res.State = cardMissing
+
+		return res
+	}
+
+	res.List = listName(tCard.ListID, lists)
+	if !s.needsMove(tCard, listID) {
+		res.WantList = res.List
+	}
+
+	cardLabels := map[string]bool{}
+
+	if tCard.IDLabels != nil {
+		for _, id := range *tCard.IDLabels {
+			cardLabels[id] = true
+
+			if _, ok := wantLabels[id]; !ok {
+				res.ExtraLabels = append(res.ExtraLabels, labelName(id, labelNames))
+			}
+		}
+	}
+
+	for _, id := range sortedKeys(wantLabels) {
+		if !cardLabels[id] {
+			res.MissingLabels = append(res.MissingLabels, wantLabels[id])
+		}
+	}
+
+	res.State = cardInSync
+	if res.List != res.WantList || len(res.MissingLabels) > 0 || len(res.ExtraLabels) > 0 {
+		res.State = cardOutOfSync
+	}
+
+	return res
+}
+
+// listName returns name of configured list or `other` for lists, which aren't used by sync.
+func listName(listID string, lists *trello.Lists) string {
+	if name := trello.GetListNameByID(listID, lists); name != "" {
+		return name
+	}
+
+	return "other"
+}
+
+func labelName(id string, labelNames map[string]string) string {
+	if name, ok := labelNames[id]; ok {
+		return name
+	}
+
+	return id
+}
+
+func printCardStatuses(out io.Writer, th *theme.Theme, statuses []*cardStatus) {
+	rows := [][]string{{"KEY", "JIRA STATUS", "LIST", "WANT LIST", "LABELS", "STATE"}}
+
+	for _, c := range statuses {
+		rows = append(rows, []string{c.Key, c.JiraStatus, c.List, c.WantList, c.labelDrift(), c.State})
+	}
+
+	writeTable(out, rows, columnWidths(rows, len(rows[0])), func(row, col int, value string) string {
+		switch {
+		case row == 0:
+			return value
+		case col == 1:
+			return th.Status(value)
+		case col == len(rows[row])-1:
+			return th.Paint(cardStateColors[value], value)
+		}
+
+		return value
+	})
+}
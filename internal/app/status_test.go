@@ -0,0 +1,54 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSyncService_status(t *testing.T) {
+	var jTasks = map[string]*jira.Task{}
+	mustLoadJSONFile(t, "testdata/test_jira_tasks.json", &jTasks)
+
+	tCards := make([]*trello.Card, 0)
+	mustLoadJSONFile(t, "testdata/test_trello_cards.json", &tCards)
+
+	tCli := GetTrelloMockedCli(tCards)
+	s := &SyncService{jCli: GetJiraMockedCli(jTasks), tCli: tCli}
+
+	statuses, err := s.status(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, statuses, 21)
+
+	byKey := map[string]*cardStatus{}
+	for _, c := range statuses {
+		byKey[c.Key] = c
+	}
+
+	require.Equal(t, &cardStatus{Key: "JIRA1-1110", JiraStatus: "ToDo", List: "Todo", WantList: "Todo",
+		State: cardInSync}, byKey["JIRA1-1110"])
+	require.Equal(t, &cardStatus{Key: "JIRA1-1194", JiraStatus: "In Dev / In Progress", WantList: "Doing",
+		State: cardMissing}, byKey["JIRA1-1194"])
+	require.Equal(t, &cardStatus{Key: "JIRA1-1324", JiraStatus: "ToDo", List: "Review", WantList: "Review",
+		State: cardInSync}, byKey["JIRA1-1324"])
+	require.Equal(t, &cardStatus{Key: "JIRA1-984", JiraStatus: "In QA Review", List: "Doing", WantList: "Review",
+		ExtraLabels: []string{"Blocked"}, State: cardOutOfSync}, byKey["JIRA1-984"])
+	require.Equal(t, &cardStatus{Key: "JIRA1-390", List: "Doing", WantList: "Done", State: cardOrphaned},
+		byKey["JIRA1-390"])
+
+	require.Empty(t, tCli.CreateCardCalls())
+	require.Empty(t, tCli.MoveCardToListCalls())
+	require.Empty(t, tCli.UpdateCardLabelsCalls())
+	require.Empty(t, tCli.UpdateCardDescCalls())
+
+	out := &bytes.Buffer{}
+	printCardStatuses(out, nil, statuses[:2])
+	require.Equal(t, `KEY            JIRA STATUS      LIST      WANT LIST     LABELS     STATE
+JIRA1-1110     ToDo             Todo      Todo                     in sync
+JIRA1-1130     Dev Complete     Doing     Review                   out of sync
+`, out.String())
+}
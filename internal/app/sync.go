@@ -22,196 +22,449 @@ THE SOFTWARE.
 package app
 
 import (
+	"context"
 	"fmt"
 	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/theme"
 	"github.com/Brialius/jira2trello/internal/trello"
 	"github.com/mattn/go-colorable"
 	"log"
+	"log/slog"
+	"os"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 )
 
 type SyncService struct {
 	jCli   JiraConnector
 	tCli   TrelloConnector
+	jql    JQLConfig
 	jTasks map[string]*jira.Task
 	tCards map[string]*trello.Card
+	// foreign keeps cards owned by other users of shared board.
+	foreign map[string]*trello.Card
+	// jiraSelf is current Jira user, it's resolved on shared board only.
+	jiraSelf *jira.User
+	// connected is set after clients are connected, they are reused by next syncs.
+	connected bool
+	members   *memberMapper
+	// fields are board custom fields by name, it's nil when custom fields are disabled.
+	fields map[string]*trello.CustomField
+	// epicLabels are label IDs by name in epics label mode, epicCards are epic cards by key in epics card mode.
+	epicLabels map[string]string
+	epicCards  map[string]*trello.Card
+	// newEpicLabels are epic labels created by sync workers by name.
+	newEpicLabels map[string]*epicLabelCreation
+	// mu guards members, fields, epicLabels and newEpicLabels, which are updated by sync workers.
+	mu     sync.Mutex
+	result *syncResult
+	theme  *theme.Theme
+	table  TableOptions
 }
 
-func NewSyncService(jCli *jira.Client, tCli TrelloConnector) *SyncService {
+func NewSyncService(jCli *jira.Client, tCli TrelloConnector, jql JQLConfig, th *theme.Theme,
+	table TableOptions) *SyncService {
 	return &SyncService{
-		jCli: jCli,
-		tCli: tCli,
+		jCli:  jCli,
+		tCli:  tCli,
+		jql:   jql,
+		theme: th,
+		table: table,
 	}
 }
 
-func (s *SyncService) Sync() {
-	var err error
+// Sync updates Trello cards by Jira tasks, the summary of changes is printed with json output.
+func (s *SyncService) Sync(ctx context.Context, output string) {
+	s.result = newSyncResult()
+
+	err := s.sync(ctx, output != OutputJSON)
+
+	s.result.addError(err)
+	s.result.sort()
+
+	if output == OutputJSON {
+		if err := writeJSON(os.Stdout, s.result); err != nil {
+			log.Fatalf("Can't write sync result: %s", err)
+		}
+	}
 
-	if err := s.jCli.Connect(); err != nil {
-		log.Fatalf("Can't connect to jira server: %s", err)
+	if err != nil {
+		log.Fatalf("Can't sync: %s", err)
 	}
+}
 
-	if err := s.tCli.Connect(); err != nil {
-		log.Fatalf("Can't connect to trello: %s", err)
+// sync updates cards, the task table is printed if printTable is set.
+func (s *SyncService) sync(ctx context.Context, printTable bool) error {
+	table, err := newTaskTable(s.table)
+	if err != nil {
+		return fmt.Errorf("can't create task table: %w", err)
 	}
 
-	fmt.Print("Getting Jira tasks... ")
+	query, err := s.jql.syncJQL(time.Now())
+	if err != nil {
+		return fmt.Errorf("can't get jira query: %w", err)
+	}
 
-	if s.jTasks, err = s.jCli.GetUserTasks("status not in (done, closed, close, resolved) " +
-		"ORDER BY priority DESC, updated DESC"); err != nil {
-		log.Fatalf("can't get jira tasks: %s", err)
+	if err := s.prepare(ctx, query); err != nil {
+		return err
 	}
 
-	fmt.Printf("found %d\n", len(s.jTasks))
+	if printTable {
+		fmt.Println()
+		table.print(colorable.NewColorableStdout(), s.theme, s.jTasks)
+		fmt.Println()
+	}
 
-	fmt.Println()
-	printJiraTasks(colorable.NewColorableStdout(), s.jTasks)
-	fmt.Println()
+	if err := s.loadCards(ctx); err != nil {
+		return err
+	}
 
-	if s.tCards, err = getTrelloCards(s.tCli); err != nil {
-		log.Fatalf("can't get trello cards: %s", err)
+	if err := s.syncTasks(ctx); err != nil {
+		return fmt.Errorf("can't sync tasks: %w", err)
 	}
 
-	if err := s.syncTasks(); err != nil {
-		log.Fatalf("can't sync tasks: %s", err)
+	if err := s.syncCompletedTasks(ctx); err != nil {
+		return fmt.Errorf("can't sync completed tasks: %w", err)
 	}
 
-	if err := s.syncCompletedTasks(); err != nil {
-		log.Fatalf("can't sync completed tasks: %s", err)
+	if err := s.syncEpicCards(ctx); err != nil {
+		return fmt.Errorf("can't sync epic cards: %w", err)
 	}
+
+	return nil
 }
 
-func (s *SyncService) syncCompletedTasks() error {
-	fmt.Println("Searching completed tasks..")
+// syncIssue updates card of the Jira issue only, the card is moved to `Done` list if the issue isn't matched
+// by sync query anymore. Epic cards aren't updated, they are updated by the next full sync.
+func (s *SyncService) syncIssue(ctx context.Context, key string) error {
+	query, err := s.jql.syncJQL(time.Now())
+	if err != nil {
+		return fmt.Errorf("can't get jira query: %w", err)
+	}
+
+	if err := s.prepare(ctx, issueJQL(key, query)); err != nil {
+		return err
+	}
 
-	for key, tCard := range s.tCards {
-		if _, ok := s.jTasks[key]; !ok {
-			if tCard.ListID != s.tCli.GetConfig().Lists.Done {
-				if err := s.tCli.MoveCardToList(tCard.ID, s.tCli.GetConfig().Lists.Done); err != nil {
-					return fmt.Errorf("can't move card to `Done` list: %w", err)
-				}
+	if err := s.loadCards(ctx); err != nil {
+		return err
+	}
 
-				fmt.Printf("%s is completed!\n", key)
-			}
-		}
+	if _, ok := s.jTasks[key]; ok {
+		return runParallel(ctx, 1, []string{key}, s.syncTask)
+	}
+
+	if tCard, ok := s.tCards[key]; ok && tCard.ListID != s.tCli.GetConfig().Lists.Done {
+		return runParallel(ctx, 1, []string{key}, s.completeTask)
 	}
 
 	return nil
 }
 
-func (s *SyncService) syncTasks() error {
-	fmt.Println("Sync tasks...")
+// connect connects to Jira and Trello once, so syncs of long running commands reuse HTTP clients and
+// Trello board instead of reconnecting each time.
+func (s *SyncService) connect(ctx context.Context) error {
+	if s.connected {
+		return nil
+	}
 
-	for key, jTask := range s.jTasks {
-		listID := s.tCli.GetConfig().Lists.Todo
-		labels := make([]string, 0)
-		labels = append(labels, s.tCli.GetConfig().Labels.Jira)
+	if err := s.jCli.Connect(ctx); err != nil {
+		return fmt.Errorf("can't connect to jira server: %w", err)
+	}
 
-		switch jTask.Status {
-		case "In Progress", "In Dev / In Progress":
-			listID = s.tCli.GetConfig().Lists.Doing
-		case "Dependency", "Blocked":
-			listID = s.tCli.GetConfig().Lists.Doing
-			labels = append(labels, s.tCli.GetConfig().Labels.Blocked)
-		case "Dev Complete", "In QA Review":
-			listID = s.tCli.GetConfig().Lists.Review
-		}
+	if err := s.tCli.Connect(ctx); err != nil {
+		return fmt.Errorf("can't connect to trello: %w", err)
+	}
+
+	s.connected = true
+
+	return nil
+}
+
+// prepare connects to Jira and Trello, gets Jira tasks of the query and prepares members, fields and epics of cards.
+func (s *SyncService) prepare(ctx context.Context, query string) error {
+	if err := s.connect(ctx); err != nil {
+		return err
+	}
+
+	slog.Info("Getting Jira tasks")
+
+	var err error
+	if s.jTasks, err = s.jCli.GetTasks(ctx, query); err != nil {
+		return fmt.Errorf("can't get jira tasks: %w", err)
+	}
+
+	slog.Info("Jira tasks found", "count", len(s.jTasks))
+
+	s.result.Issues = jiraTasksToTasks(s.jTasks)
+
+	if err := s.prepareMembers(ctx); err != nil {
+		return fmt.Errorf("can't prepare card members: %w", err)
+	}
+
+	if err := s.prepareCustomFields(ctx); err != nil {
+		return fmt.Errorf("can't prepare custom fields: %w", err)
+	}
+
+	if err := s.prepareEpics(ctx); err != nil {
+		return fmt.Errorf("can't prepare epics: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SyncService) loadCards(ctx context.Context) error {
+	var err error
 
-		switch jTask.Type {
-		case "Story":
-			labels = append(labels, s.tCli.GetConfig().Labels.Story)
-		case "User Story":
-			labels = append(labels, s.tCli.GetConfig().Labels.Story)
-		case "Bug":
-			labels = append(labels, s.tCli.GetConfig().Labels.Bug)
-		default:
-			labels = append(labels, s.tCli.GetConfig().Labels.Task)
+	if s.tCli.GetConfig().Shared {
+		if err := s.prepareJiraSelf(ctx); err != nil {
+			return err
 		}
 
-		if tCard, ok := s.tCards[key]; !ok {
-			if err := s.addCardToList(jTask, listID, key, labels); err != nil {
-				return fmt.Errorf("can't add Task to list: %w", err)
-			}
-		} else {
-			if err := s.updateCardLabels(tCard, labels); err != nil {
-				return err
-			}
-			if err := s.updateCardList(tCard, listID, jTask); err != nil {
-				return err
-			}
+		s.tCards, s.foreign, err = getSharedTrelloCards(ctx, s.tCli)
+	} else {
+		s.tCards, err = getTrelloCards(ctx, s.tCli)
+	}
+
+	if err != nil {
+		return fmt.Errorf("can't get trello cards: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SyncService) syncCompletedTasks(ctx context.Context) error {
+	slog.Info("Searching completed tasks")
+
+	done := s.tCli.GetConfig().Lists.Done
+	keys := make([]string, 0)
+
+	for key, tCard := range s.tCards {
+		if _, ok := s.jTasks[key]; !ok && tCard.ListID != done {
+			keys = append(keys, key)
 		}
 	}
 
+	sort.Strings(keys)
+
+	return runParallel(ctx, s.tCli.GetConfig().Workers, keys, s.completeTask)
+}
+
+// completeTask moves card of the task, which isn't matched by sync query anymore, to `Done` list.
+func (s *SyncService) completeTask(ctx context.Context, key string) error {
+	done := s.tCli.GetConfig().Lists.Done
+
+	if err := s.tCli.MoveCardToList(ctx, s.tCards[key].ID, done); err != nil {
+		return fmt.Errorf("can't move card to `Done` list: %w", err)
+	}
+
+	slog.Info("Task completed", "key", key)
+	s.result.addMoved(key, trello.GetListNameByID(done, s.tCli.GetConfig().Lists))
+
 	return nil
 }
 
-func (s *SyncService) updateCardList(tCard *trello.Card, listID string, task *jira.Task) error {
-	if tCard.ListID != listID {
-		if listID == s.tCli.GetConfig().Lists.Doing || listID == s.tCli.GetConfig().Lists.Todo {
-			if tCard.IsInAnyOfLists([]string{
-				s.tCli.GetConfig().Lists.Bucket,
-				s.tCli.GetConfig().Lists.Review,
-			}) {
+// syncTasks updates cards of Jira tasks in parallel, updates of each card are made by one worker.
+func (s *SyncService) syncTasks(ctx context.Context) error {
+	slog.Info("Syncing tasks")
+
+	return runParallel(ctx, s.tCli.GetConfig().Workers, sortedKeys(s.jTasks), s.syncTask)
+}
+
+func (s *SyncService) syncTask(ctx context.Context, key string) error {
+	jTask := s.jTasks[key]
+	listID, labels := s.taskListAndLabels(jTask)
+
+	epicLabel, err := s.epicLabel(ctx, jTask)
+	if err != nil {
+		return err
+	}
+
+	if epicLabel != "" {
+		labels = append(labels, epicLabel)
+	}
+
+	tCard, ok := s.tCards[key]
+
+	if !ok {
+		if tCard, ok = s.foreign[key]; ok {
+			if !s.isCurrentJiraUser(jTask.Assignee) {
+				slog.Info("Skipping card owned by another user", "key", key)
+
 				return nil
 			}
+
+			slog.Info("Taking ownership of card", "key", key)
+		}
+	}
+
+	if !ok {
+		if err := s.addCardToList(ctx, jTask, listID, key, labels); err != nil {
+			return fmt.Errorf("can't add Task to list: %w", err)
 		}
 
-		fmt.Printf("Moving %s to %s list\n", task.Key, trello.GetListNameByID(listID, s.tCli.GetConfig().Lists))
-		err := s.tCli.MoveCardToList(tCard.ID, listID)
+		return nil
+	}
+
+	if err := s.updateCardMarker(ctx, tCard, jTask); err != nil {
+		return err
+	}
+
+	if err := s.updateCardLabels(ctx, tCard, labels); err != nil {
+		return err
+	}
+
+	if err := s.updateCardList(ctx, tCard, listID, jTask); err != nil {
+		return err
+	}
+
+	if err := s.updateCardMembers(ctx, tCard, jTask); err != nil {
+		return err
+	}
+
+	return s.updateCardFields(ctx, tCard, jTask)
+}
+
+// taskListAndLabels returns list of the task card by Jira status and its labels by status and type,
+// epic label isn't included.
+func (s *SyncService) taskListAndLabels(jTask *jira.Task) (string, []string) {
+	listID := s.tCli.GetConfig().Lists.Todo
+	labels := make([]string, 0)
+	labels = append(labels, s.tCli.GetConfig().Labels.Jira)
+
+	switch jTask.Status {
+	case "In Progress", "In Dev / In Progress":
+		listID = s.tCli.GetConfig().Lists.Doing
+	case "Dependency", "Blocked":
+		listID = s.tCli.GetConfig().Lists.Doing
+		labels = append(labels, s.tCli.GetConfig().Labels.Blocked)
+	case "Dev Complete", "In QA Review":
+		listID = s.tCli.GetConfig().Lists.Review
+	}
+
+	switch jTask.Type {
+	case "Story":
+		labels = append(labels, s.tCli.GetConfig().Labels.Story)
+	case "User Story":
+		labels = append(labels, s.tCli.GetConfig().Labels.Story)
+	case "Bug":
+		labels = append(labels, s.tCli.GetConfig().Labels.Bug)
+	default:
+		labels = append(labels, s.tCli.GetConfig().Labels.Task)
+	}
+
+	return listID, labels
+}
+
+// needsMove reports whether card should be moved to the list, cards aren't moved from `Bucket` and `Review`
+// lists back to `Todo` and `Doing` lists.
+func (s *SyncService) needsMove(tCard *trello.Card, listID string) bool {
+	if tCard.ListID == listID {
+		return false
+	}
+
+	if listID == s.tCli.GetConfig().Lists.Doing || listID == s.tCli.GetConfig().Lists.Todo {
+		return !tCard.IsInAnyOfLists([]string{
+			s.tCli.GetConfig().Lists.Bucket,
+			s.tCli.GetConfig().Lists.Review,
+		})
+	}
+
+	return true
+}
+
+func (s *SyncService) updateCardList(ctx context.Context, tCard *trello.Card, listID string, task *jira.Task) error {
+	if s.needsMove(tCard, listID) {
+		list := trello.GetListNameByID(listID, s.tCli.GetConfig().Lists)
+
+		slog.Info("Moving card", "key", task.Key, "list", list)
+		err := s.tCli.MoveCardToList(ctx, tCard.ID, listID)
 
 		if err != nil {
 			return fmt.Errorf("can't move card to list: %w", err)
 		}
+
+		s.result.addMoved(task.Key, list)
 	}
 
 	return nil
 }
 
-func (s *SyncService) updateCardLabels(tCard *trello.Card, labels []string) error {
+func (s *SyncService) updateCardLabels(ctx context.Context, tCard *trello.Card, labels []string) error {
 	if !reflect.DeepEqual(*tCard.IDLabels, labels) {
-		fmt.Printf("Updating labels for %s\n", tCard.Key)
-		err := s.tCli.UpdateCardLabels(tCard.ID, strings.Join(labels, ","))
+		slog.Info("Updating card labels", "key", tCard.Key)
+		err := s.tCli.UpdateCardLabels(ctx, tCard.ID, strings.Join(labels, ","))
 
 		if err != nil {
 			return fmt.Errorf("can't update labels on card `%s`: %w", tCard.Key, err)
 		}
+
+		s.result.addRelabeled(tCard.Key)
 	}
 
 	return nil
 }
 
-func (s *SyncService) addCardToList(task *jira.Task, listID string, key string, labels []string) error {
-	fmt.Printf("Adding %s to %s list..\n", task.Key, trello.GetListNameByID(listID, s.tCli.GetConfig().Lists))
+func (s *SyncService) addCardToList(ctx context.Context, task *jira.Task, listID string, key string,
+	labels []string) error {
+	list := trello.GetListNameByID(listID, s.tCli.GetConfig().Lists)
+
+	slog.Info("Adding card", "key", task.Key, "list", list)
 	desc := task.Desc + "\nJira link: " + task.Link + "\nType: " + task.Type
 
 	if task.ParentKey != "" {
 		desc += "\nParent link: " + task.ParentLink
 	}
 
-	return s.tCli.CreateCard(&trello.Card{
+	card := &trello.Card{
 		Name:      key + " | " + task.Summary,
 		ListID:    listID,
 		Desc:      desc,
 		IDLabels:  &labels,
-		IDMembers: s.tCli.GetConfig().UserID,
-	})
+		IDMembers: s.cardMembers(task, nil),
+		Marker:    s.cardMarker(task),
+	}
+
+	if err := s.tCli.CreateCard(ctx, card); err != nil {
+		// todo: error returned from interface method should be wrapped
+		return err
+	}
+
+	s.result.addCreated(key, list)
+
+	return s.updateCardFields(ctx, card, task)
 }
 
-func getTrelloCards(tCli TrelloConnector) (map[string]*trello.Card, error) {
-	fmt.Print("Getting Trello cards... ")
+func getTrelloCards(ctx context.Context, tCli TrelloConnector) (map[string]*trello.Card, error) {
+	slog.Info("Getting Trello cards")
 
 	tCards := map[string]*trello.Card{}
 
-	cards, err := tCli.GetUserJiraCards()
+	cards, err := tCli.GetUserJiraCards(ctx)
 	if err != nil {
 		// todo: error returned from interface method should be wrapped
 		return nil, err
 	}
 
-	fmt.Printf("found %d\n", len(cards))
+	slog.Info("Trello cards found", "count", len(cards))
 
 	for _, card := range cards {
+		if card.Key == "" {
+			continue
+		}
+
+		// The oldest card is kept for duplicate keys, duplicates can be removed with `cleanup` command.
+		if dup, ok := tCards[card.Key]; ok {
+			slog.Warn("Duplicate cards found, run cleanup to fix it", "key", card.Key)
+
+			if dup.ID < card.ID {
+				continue
+			}
+		}
+
 		tCards[card.Key] = card
 	}
 
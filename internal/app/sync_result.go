@@ -0,0 +1,98 @@
+package app
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+// syncResult is a summary of sync printed with json output, it's updated by sync workers.
+// Changes and errors are ignored by nil result.
+type syncResult struct {
+	mu        sync.Mutex
+	Issues    []*Task       `json:"issues"`
+	Created   []*cardChange `json:"created"`
+	Moved     []*cardChange `json:"moved"`
+	Relabeled []*cardChange `json:"relabeled"`
+	Errors    []*syncError  `json:"errors"`
+}
+
+// cardChange is a card of Jira issue changed by sync, List is a name of target list.
+type cardChange struct {
+	Key  string `json:"key"`
+	List string `json:"list,omitempty"`
+}
+
+// syncError is an error of the card or the whole sync if key is empty.
+type syncError struct {
+	Key   string `json:"key,omitempty"`
+	Error string `json:"error"`
+}
+
+func newSyncResult() *syncResult {
+	return &syncResult{
+		Issues:    make([]*Task, 0),
+		Created:   make([]*cardChange, 0),
+		Moved:     make([]*cardChange, 0),
+		Relabeled: make([]*cardChange, 0),
+		Errors:    make([]*syncError, 0),
+	}
+}
+
+func (r *syncResult) addCreated(key, list string) {
+	if r != nil {
+		r.add(&r.Created, key, list)
+	}
+}
+
+func (r *syncResult) addMoved(key, list string) {
+	if r != nil {
+		r.add(&r.Moved, key, list)
+	}
+}
+
+func (r *syncResult) addRelabeled(key string) {
+	if r != nil {
+		r.add(&r.Relabeled, key, "")
+	}
+}
+
+func (r *syncResult) add(changes *[]*cardChange, key, list string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	*changes = append(*changes, &cardChange{Key: key, List: list})
+}
+
+// addError adds sync error, errors of cards are added by key.
+func (r *syncResult) addError(err error) {
+	if r == nil || err == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var errs cardErrors
+	if !errors.As(err, &errs) {
+		r.Errors = append(r.Errors, &syncError{Error: err.Error()})
+
+		return
+	}
+
+	for _, key := range sortedKeys(errs) {
+		r.Errors = append(r.Errors, &syncError{Key: key, Error: errs[key].Error()})
+	}
+}
+
+// sort orders changes by key, since cards are updated in parallel.
+func (r *syncResult) sort() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, changes := range [][]*cardChange{r.Created, r.Moved, r.Relabeled} {
+		sort.SliceStable(changes, func(i, j int) bool {
+			return changes[i].Key < changes[j].Key
+		})
+	}
+}
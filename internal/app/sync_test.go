@@ -23,8 +23,12 @@ package app
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/theme"
 	"github.com/Brialius/jira2trello/internal/trello"
 	"github.com/mattn/go-colorable"
 	"github.com/stretchr/testify/require"
@@ -85,8 +89,11 @@ ToDo                     Sub-task     JIRA1-1324     Task name 1324     21 Aug 2
 			s := &SyncService{
 				jTasks: tt.fields.jTasks,
 			}
+			table, err := newTaskTable(TableOptions{})
+			require.NoError(t, err)
+
 			out := &bytes.Buffer{}
-			printJiraTasks(colorable.NewNonColorable(out), s.jTasks)
+			table.print(colorable.NewNonColorable(out), nil, s.jTasks)
 			if gotOut := out.String(); gotOut != tt.wantOut {
 				t.Errorf("printJiraTasks() = %v, want %v", gotOut, tt.wantOut)
 			}
@@ -94,6 +101,29 @@ ToDo                     Sub-task     JIRA1-1324     Task name 1324     21 Aug 2
 	}
 }
 
+func Test_printJiraTasksColors(t *testing.T) {
+	var jTasks = map[string]*jira.Task{}
+	mustLoadJSONFile(t, "testdata/test_jira_tasks.json", &jTasks)
+
+	th, err := theme.New(theme.Config{Status: map[string]string{"todo": "none"}}, true)
+	require.NoError(t, err)
+
+	table, err := newTaskTable(TableOptions{})
+	require.NoError(t, err)
+
+	plain := &bytes.Buffer{}
+	table.print(plain, nil, jTasks)
+
+	colored := &bytes.Buffer{}
+	table.print(colored, th, jTasks)
+	require.Contains(t, colored.String(), theme.Yellow+"In Dev / In Progress"+theme.ColorOff)
+
+	stripped := &bytes.Buffer{}
+	_, err = colorable.NewNonColorable(stripped).Write(colored.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, plain.String(), stripped.String())
+}
+
 func mustLoadJSONFile(t *testing.T, file string, variable any) []byte {
 	testFileContent, err := os.ReadFile(file)
 	require.NoError(t, err)
@@ -145,13 +175,21 @@ func TestSyncService_Sync(t *testing.T) {
 				jTasks: tt.fields.jTasks,
 				tCards: tt.fields.tCards,
 			}
-			s.Sync()
+			s.Sync(context.Background(), OutputText)
 
 			require.Equal(t, calls{
 				{"098098098098098098098011", "121212121212121212121fa4,12121212121212121212a0c8"}},
-				calls(tCli.UpdateCardLabelsCalls()))
+				stringCalls(tCli.UpdateCardLabelsCalls()))
+
+			require.Len(t, tCli.UpdateCardDescCalls(), 19)
 
-			moveCalls := calls(tCli.MoveCardToListCalls())
+			for _, c := range tCli.UpdateCardDescCalls() {
+				marker, ok := trello.ParseMarker(c.S2)
+				require.True(t, ok)
+				require.Equal(t, "111111111111111111111111", marker.Owner)
+			}
+
+			moveCalls := stringCalls(tCli.MoveCardToListCalls())
 			sort.Sort(moveCalls)
 
 			require.Equal(t, calls{
@@ -161,13 +199,71 @@ func TestSyncService_Sync(t *testing.T) {
 			},
 				moveCalls)
 
-			require.Equal(t, []struct{ Card *trello.Card }{{Card: &trello.Card{
+			created := tCli.CreateCardCalls()
+			require.Len(t, created, 1)
+			require.Equal(t, &trello.Card{
 				Name:      "JIRA1-1194 | Task name 1194",
 				ListID:    "12345678909876543219d1cb",
 				Desc:      "\nJira link: https://jira-site/browse/JIRA1-1194\nType: Bug",
 				IDLabels:  &[]string{"121212121212121212121fa4", "12121212121212121212de33"},
 				IDMembers: "111111111111111111111111",
-			}}}, tCli.CreateCardCalls())
+				Marker: &trello.Marker{
+					Key:   "JIRA1-1194",
+					Link:  "https://jira-site/browse/JIRA1-1194",
+					Owner: "111111111111111111111111",
+				},
+			}, created[0].Card)
+
+			require.Len(t, s.result.Issues, len(jTasks))
+			require.Equal(t, []*cardChange{{Key: "JIRA1-1194", List: "Doing"}}, s.result.Created)
+			require.Equal(t, []*cardChange{
+				{Key: "JIRA1-1130", List: "Review"},
+				{Key: "JIRA1-390", List: "Done"},
+				{Key: "JIRA1-984", List: "Review"},
+			}, s.result.Moved)
+			require.Equal(t, []*cardChange{{Key: "JIRA1-984"}}, s.result.Relabeled)
+			require.Empty(t, s.result.Errors)
+		})
+	}
+}
+
+func Test_syncResult_addError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want []*syncError
+	}{
+		{
+			name: "no error",
+			err:  nil,
+			want: []*syncError{},
+		},
+		{
+			name: "sync error",
+			err:  errors.New("can't connect to trello: unauthorized"),
+			want: []*syncError{{Error: "can't connect to trello: unauthorized"}},
+		},
+		{
+			name: "card errors",
+			err: fmt.Errorf("can't sync tasks: %w", cardErrors{
+				"JIRA1-2": errors.New("can't move card"),
+				"JIRA1-1": errors.New("can't update labels"),
+			}),
+			want: []*syncError{
+				{Key: "JIRA1-1", Error: "can't update labels"},
+				{Key: "JIRA1-2", Error: "can't move card"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newSyncResult()
+			r.addError(tt.err)
+			require.Equal(t, tt.want, r.Errors)
+
+			out := &bytes.Buffer{}
+			require.NoError(t, writeJSON(out, r))
+			require.True(t, json.Valid(out.Bytes()))
 		})
 	}
 }
@@ -186,12 +282,27 @@ func (c calls) Swap(a, b int) {
 	c[a], c[b] = c[b], c[a]
 }
 
+// stringCalls returns string arguments of mock calls without context.
+func stringCalls(mockCalls []struct {
+	ContextMoqParam context.Context
+	S1              string
+	S2              string
+}) calls {
+	res := make(calls, 0, len(mockCalls))
+
+	for _, c := range mockCalls {
+		res = append(res, struct{ S1, S2 string }{c.S1, c.S2})
+	}
+
+	return res
+}
+
 func GetJiraMockedCli(jTasks map[string]*jira.Task) *JiraConnectorMock {
 	return &JiraConnectorMock{
-		ConnectFunc: func() error {
+		ConnectFunc: func(ctx context.Context) error {
 			return nil
 		},
-		GetUserTasksFunc: func(jql string) (map[string]*jira.Task, error) {
+		GetTasksFunc: func(ctx context.Context, jql string) (map[string]*jira.Task, error) {
 			return jTasks, nil
 		},
 	}
@@ -199,33 +310,33 @@ func GetJiraMockedCli(jTasks map[string]*jira.Task) *JiraConnectorMock {
 
 func GetTrelloMockedCli(tCards []*trello.Card) *TrelloConnectorMock {
 	return &TrelloConnectorMock{
-		ConnectFunc: func() error {
+		ConnectFunc: func(ctx context.Context) error {
 			return nil
 		},
-		CreateCardFunc: func(in1 *trello.Card) error {
+		CreateCardFunc: func(ctx context.Context, in1 *trello.Card) error {
 			return nil
 		},
-		GetBoardsFunc: func() (map[string]*trello.Board, error) {
+		GetBoardsFunc: func(ctx context.Context) (map[string]*trello.Board, error) {
 			return map[string]*trello.Board{
 				"Board1": {
-					"https://trello.com/b/0/board1",
-					"Board",
-					"000000000000000000000000",
+					URL:  "https://trello.com/b/0/board1",
+					Name: "Board",
+					ID:   "000000000000000000000000",
 				},
 				"Board2": {
-					"https://trello.com/b/1/board2",
-					"Board",
-					"111111111111111111111111",
+					URL:  "https://trello.com/b/1/board2",
+					Name: "Board",
+					ID:   "111111111111111111111111",
 				},
 				"Board3": {
-					"https://trello.com/b/2/board3",
-					"Board",
-					"222222222222222222222222",
+					URL:  "https://trello.com/b/2/board3",
+					Name: "Board",
+					ID:   "222222222222222222222222",
 				},
 				"Board4": {
-					"https://trello.com/b/3/board4",
-					"Board",
-					"33333333333333333333333",
+					URL:  "https://trello.com/b/3/board4",
+					Name: "Board",
+					ID:   "33333333333333333333333",
 				},
 			}, nil
 		},
@@ -249,34 +360,37 @@ func GetTrelloMockedCli(tCards []*trello.Card) *TrelloConnectorMock {
 				Debug: false,
 			}
 		},
-		GetLabelsFunc: func() (map[string]*trello.Label, error) {
+		GetLabelsFunc: func(ctx context.Context) (map[string]*trello.Label, error) {
 			return map[string]*trello.Label{
-				"Jira":    {"Jira", "121212121212121212121fa4"},
-				"Blocked": {"Blocked", "12121212121212121212d298"},
-				"Task":    {"Task", "121212121212121212121795"},
-				"Bug":     {"Bug", "12121212121212121212de33"},
-				"Story":   {"Story", "12121212121212121212a0c8"},
+				"Jira":    {Name: "Jira", ID: "121212121212121212121fa4"},
+				"Blocked": {Name: "Blocked", ID: "12121212121212121212d298"},
+				"Task":    {Name: "Task", ID: "121212121212121212121795"},
+				"Bug":     {Name: "Bug", ID: "12121212121212121212de33"},
+				"Story":   {Name: "Story", ID: "12121212121212121212a0c8"},
 			}, nil
 		},
-		GetListsFunc: func() (map[string]*trello.List, error) {
+		GetListsFunc: func(ctx context.Context) (map[string]*trello.List, error) {
 			return map[string]*trello.List{
-				"Todo":   {"Todo", "12345678909876543219d1c9"},
-				"Doing":  {"Doing", "12345678909876543219d1cb"},
-				"Done":   {"Done", "12345678909876543219d1cf"},
-				"Review": {"Review", "12345678909876543219d1cc"},
-				"Bucket": {"Bucket", "12345678909876543219d1d0"},
+				"Todo":   {Name: "Todo", ID: "12345678909876543219d1c9"},
+				"Doing":  {Name: "Doing", ID: "12345678909876543219d1cb"},
+				"Done":   {Name: "Done", ID: "12345678909876543219d1cf"},
+				"Review": {Name: "Review", ID: "12345678909876543219d1cc"},
+				"Bucket": {Name: "Bucket", ID: "12345678909876543219d1d0"},
 			}, nil
 		},
-		GetUserJiraCardsFunc: func() ([]*trello.Card, error) {
+		GetUserJiraCardsFunc: func(ctx context.Context) ([]*trello.Card, error) {
 			return tCards, nil
 		},
-		MoveCardToListFunc: func(in1 string, in2 string) error {
+		MoveCardToListFunc: func(ctx context.Context, in1 string, in2 string) error {
+			return nil
+		},
+		SetBoardFunc: func(ctx context.Context) error {
 			return nil
 		},
-		SetBoardFunc: func() error {
+		UpdateCardLabelsFunc: func(ctx context.Context, in1 string, in2 string) error {
 			return nil
 		},
-		UpdateCardLabelsFunc: func(in1 string, in2 string) error {
+		UpdateCardDescFunc: func(ctx context.Context, in1 string, in2 string) error {
 			return nil
 		},
 	}
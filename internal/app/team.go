@@ -0,0 +1,141 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"strings"
+)
+
+// TeamMember maps team member Jira user to Trello member.
+type TeamMember struct {
+	Name string
+	// Jira username.
+	Jira string
+	// Trello member username or ID.
+	Trello string
+}
+
+func (m TeamMember) displayName() string {
+	if m.Name != "" {
+		return m.Name
+	}
+
+	if m.Jira != "" {
+		return m.Jira
+	}
+
+	return m.Trello
+}
+
+// teamTrelloSections returns report section per team member based on cards, where member is assigned.
+func teamTrelloSections(ctx context.Context, tCli TrelloConnector, team []TeamMember,
+	jiraURL string) ([]*reportSection, error) {
+	if err := tCli.Connect(ctx); err != nil {
+		return nil, fmt.Errorf("can't connect to trello: %w", err)
+	}
+
+	members, err := tCli.GetMembers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can't get board members: %w", err)
+	}
+
+	sections := make([]*reportSection, 0, len(team))
+
+	for _, m := range team {
+		if m.Trello == "" {
+			return nil, fmt.Errorf("trello member of team member `%s` is not configured", m.displayName())
+		}
+
+		member := findMember(members, m.Trello)
+		if member == nil {
+			return nil, fmt.Errorf("trello member `%s` of team member `%s` is not found on the board",
+				m.Trello, m.displayName())
+		}
+
+		name := m.displayName()
+		if m.Name == "" && member.FullName != "" {
+			name = member.FullName
+		}
+
+		cards, err := tCli.GetMemberJiraCards(ctx, member.ID)
+		if err != nil {
+			return nil, fmt.Errorf("can't get trello cards of `%s`: %w", name, err)
+		}
+
+		tasks := cardsToTasks(tCli.GetConfig().Lists, cards, jiraURL)
+		for _, t := range tasks {
+			t.Assignee = name
+		}
+
+		sections = append(sections, &reportSection{
+			Name:  name,
+			Tasks: tasks,
+		})
+	}
+
+	return sections, nil
+}
+
+// findMember returns board member by username or ID.
+func findMember(members map[string]*trello.Member, nameOrID string) *trello.Member {
+	if member, ok := members[nameOrID]; ok {
+		return member
+	}
+
+	for _, member := range members {
+		if member.ID == nameOrID {
+			return member
+		}
+	}
+
+	return nil
+}
+
+// teamJiraSections returns report section per team member based on Jira tasks assigned to the member.
+func teamJiraSections(ctx context.Context, jCli JiraConnector, team []TeamMember, dateRange DateRange,
+	jql JQLConfig) ([]*reportSection, error) {
+	if err := jql.checkWeeklyUser(dateRange); err != nil {
+		return nil, err
+	}
+
+	if err := jCli.Connect(ctx); err != nil {
+		return nil, fmt.Errorf("can't connect to jira server: %w", err)
+	}
+
+	sections := make([]*reportSection, 0, len(team))
+
+	for _, m := range team {
+		if m.Jira == "" {
+			return nil, fmt.Errorf("jira user of team member `%s` is not configured", m.displayName())
+		}
+
+		query, err := jql.weeklyJQL(dateRange, m.Jira)
+		if err != nil {
+			return nil, err
+		}
+
+		jTasks, err := jCli.GetTasks(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("can't get jira tasks of `%s`: %w", m.displayName(), err)
+		}
+
+		tasks := jiraTasksToTasks(jTasks)
+		for _, t := range tasks {
+			t.Assignee = m.displayName()
+		}
+
+		sections = append(sections, &reportSection{
+			Name:  m.displayName(),
+			Tasks: tasks,
+		})
+	}
+
+	return sections, nil
+}
+
+var jqlQuoteReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+func jqlQuote(s string) string {
+	return `"` + jqlQuoteReplacer.Replace(s) + `"`
+}
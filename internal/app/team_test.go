@@ -0,0 +1,120 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func Test_teamTrelloSections(t *testing.T) {
+	tCli := GetTrelloMockedCli(nil)
+	tCli.GetMembersFunc = func(ctx context.Context) (map[string]*trello.Member, error) {
+		return map[string]*trello.Member{
+			"alice": {Name: "alice", FullName: "Alice Smith", ID: "222222222222222222222222"},
+			"bob":   {Name: "bob", ID: "333333333333333333333333"},
+		}, nil
+	}
+	tCli.GetMemberJiraCardsFunc = func(ctx context.Context, memberID string) ([]*trello.Card, error) {
+		switch memberID {
+		case "222222222222222222222222":
+			return []*trello.Card{
+				{Key: "JIRA1-2", Name: "JIRA1-2 | Alice task", ListID: "12345678909876543219d1cb"},
+			}, nil
+		case "333333333333333333333333":
+			return []*trello.Card{
+				{Key: "JIRA1-3", Name: "JIRA1-3 | Bob task", ListID: "12345678909876543219d1cf"},
+				{Key: "JIRA1-4", Name: "JIRA1-4 | Bob bucket", ListID: "12345678909876543219d1d0"},
+			}, nil
+		}
+
+		return nil, nil
+	}
+
+	sections, err := teamTrelloSections(context.Background(), tCli, []TeamMember{
+		{Jira: "alice", Trello: "alice"},
+		{Name: "Bob", Jira: "bob", Trello: "333333333333333333333333"},
+	}, "https://jira-site")
+	require.NoError(t, err)
+
+	require.Equal(t, []*reportSection{
+		{
+			Name: "Alice Smith",
+			Tasks: []*Task{{
+				Name: "Alice task", Status: doingString, Link: "https://jira-site/browse/JIRA1-2",
+				Key: "JIRA1-2", Assignee: "Alice Smith",
+			}},
+		},
+		{
+			Name: "Bob",
+			Tasks: []*Task{{
+				Name: "Bob task", Status: doneString, Link: "https://jira-site/browse/JIRA1-3",
+				Key: "JIRA1-3", Assignee: "Bob",
+			}},
+		},
+	}, sections)
+
+	// Cards of all members are matched by empty ID, so member must be resolved.
+	_, err = teamTrelloSections(context.Background(), tCli, []TeamMember{{Name: "Carol", Jira: "carol"}},
+		"https://jira-site")
+	require.EqualError(t, err, "trello member of team member `Carol` is not configured")
+
+	_, err = teamTrelloSections(context.Background(), tCli, []TeamMember{{Name: "Dave", Trello: "dave"}},
+		"https://jira-site")
+	require.EqualError(t, err, "trello member `dave` of team member `Dave` is not found on the board")
+
+	r, err := newReport(ReportOptions{Format: "html"}, defaultDateRange(time.Now()), nil)
+	require.NoError(t, err)
+
+	r.Year = 2000
+	r.WeekNumber = 1
+	r.Sections = sections
+
+	out := &bytes.Buffer{}
+	require.NoError(t, r.generate(out))
+	require.Contains(t, out.String(), `	<h1>Report 2000 week 1</h1>
+	<h2>Alice Smith</h2>
+	<ul>
+	<li><a href=https://jira-site/browse/JIRA1-2>JIRA1-2</a> | Alice task - <strong>In progress</strong></li>
+	</ul>
+	<h2>Bob</h2>
+	<ul>
+	<li><a href=https://jira-site/browse/JIRA1-3>JIRA1-3</a> | Bob task - <strong>Done</strong></li>
+	</ul>
+</body>`)
+}
+
+func Test_teamJiraSections(t *testing.T) {
+	queries := make([]string, 0)
+	jCli := GetJiraMockedCli(nil)
+	jCli.GetTasksFunc = func(ctx context.Context, jql string) (map[string]*jira.Task, error) {
+		queries = append(queries, jql)
+
+		return map[string]*jira.Task{
+			"JIRA1-1": {Key: "JIRA1-1", Summary: "Task 1", Status: "Closed"},
+		}, nil
+	}
+
+	dateRange, err := parseISOWeek("2026-W41", time.UTC)
+	require.NoError(t, err)
+
+	sections, err := teamJiraSections(context.Background(), jCli,
+		[]TeamMember{{Name: "Bob", Jira: `bob "the builder"`}}, dateRange,
+		JQLConfig{Weekly: "assignee = {{ .User }} AND updated >= {{ .Since }}"})
+	require.NoError(t, err)
+	require.Len(t, sections, 1)
+	require.Equal(t, "Bob", sections[0].Tasks[0].Assignee)
+	require.Equal(t, []string{`assignee = "bob \"the builder\"" AND updated >= "2026-10-05 00:00"`}, queries)
+
+	_, err = teamJiraSections(context.Background(), jCli, []TeamMember{{Name: "Bob", Trello: "bob"}}, dateRange,
+		JQLConfig{})
+	require.Error(t, err)
+
+	_, err = teamJiraSections(context.Background(), jCli, []TeamMember{{Name: "Bob", Jira: "bob"}}, dateRange,
+		JQLConfig{Weekly: "assignee = currentUser() AND updated >= {{ .Since }}"})
+	require.ErrorContains(t, err, "doesn't use {{ .User }}")
+	require.Len(t, queries, 1)
+}
@@ -1,19 +1,41 @@
 package app
 
-import "github.com/Brialius/jira2trello/internal/trello"
+import (
+	"context"
+	"github.com/Brialius/jira2trello/internal/trello"
+)
 
 //go:generate moq -out trello_connector_moq_test.go . TrelloConnector
 
 type TrelloConnector interface {
-	Connect() error
-	GetBoards() (map[string]*trello.Board, error)
-	GetLists() (map[string]*trello.List, error)
-	GetLabels() (map[string]*trello.Label, error)
-	GetUserJiraCards() ([]*trello.Card, error)
-	CreateCard(*trello.Card) error
-	MoveCardToList(string, string) error
-	UpdateCardLabels(string, string) error
-	SetBoard() error
+	Connect(context.Context) error
+	GetBoards(context.Context) (map[string]*trello.Board, error)
+	GetLists(context.Context) (map[string]*trello.List, error)
+	GetLabels(context.Context) (map[string]*trello.Label, error)
+	GetUserJiraCards(context.Context) ([]*trello.Card, error)
+	GetMemberJiraCards(context.Context, string) ([]*trello.Card, error)
+	GetJiraCards(context.Context) ([]*trello.Card, error)
+	GetMembers(context.Context) (map[string]*trello.Member, error)
+	CreateCard(context.Context, *trello.Card) error
+	MoveCardToList(context.Context, string, string) error
+	UpdateCardLabels(context.Context, string, string) error
+	UpdateCardMembers(context.Context, string, string) error
+	UpdateCardDesc(context.Context, string, string) error
+	SetBoard(context.Context) error
 	GetConfig() *trello.Config
-	ArchiveAllCardsInList(string) error
+	ArchiveAllCardsInList(context.Context, string) ([]*trello.Card, error)
+	UnarchiveCard(context.Context, string) error
+	GetCustomFields(context.Context) (map[string]*trello.CustomField, error)
+	CreateCustomField(context.Context, string, string) (*trello.CustomField, error)
+	CreateCustomFieldOption(context.Context, string, string) (string, error)
+	SetCardCustomField(context.Context, string, *trello.CustomField, string) error
+	GetEpicCards(context.Context) ([]*trello.Card, error)
+	CreateLabel(context.Context, string, string) (*trello.Label, error)
+	AddCheckItem(context.Context, *trello.Card, string) error
+	SetCheckItemState(context.Context, string, string, bool) error
+	GetBoardCards(context.Context) ([]*trello.Card, error)
+	ArchiveCard(context.Context, string) error
+	GetCard(context.Context, string) (*trello.Card, error)
+	UpdateCardName(context.Context, string, string) error
+	RegisterWebhook(context.Context, string) error
 }
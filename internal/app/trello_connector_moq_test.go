@@ -4,6 +4,7 @@
 package app
 
 import (
+	"context"
 	"github.com/Brialius/jira2trello/internal/trello"
 	"sync"
 )
@@ -18,39 +19,96 @@ var _ TrelloConnector = &TrelloConnectorMock{}
 //
 //		// make and configure a mocked TrelloConnector
 //		mockedTrelloConnector := &TrelloConnectorMock{
-//			ArchiveAllCardsInListFunc: func(s string) error {
+//			AddCheckItemFunc: func(contextMoqParam context.Context, card *trello.Card, s string) error {
+//				panic("mock out the AddCheckItem method")
+//			},
+//			ArchiveAllCardsInListFunc: func(contextMoqParam context.Context, s string) ([]*trello.Card, error) {
 //				panic("mock out the ArchiveAllCardsInList method")
 //			},
-//			ConnectFunc: func() error {
+//			ArchiveCardFunc: func(contextMoqParam context.Context, s string) error {
+//				panic("mock out the ArchiveCard method")
+//			},
+//			ConnectFunc: func(contextMoqParam context.Context) error {
 //				panic("mock out the Connect method")
 //			},
-//			CreateCardFunc: func(card *trello.Card) error {
+//			CreateCardFunc: func(contextMoqParam context.Context, card *trello.Card) error {
 //				panic("mock out the CreateCard method")
 //			},
-//			GetBoardsFunc: func() (map[string]*trello.Board, error) {
+//			CreateCustomFieldFunc: func(contextMoqParam context.Context, s1 string, s2 string) (*trello.CustomField, error) {
+//				panic("mock out the CreateCustomField method")
+//			},
+//			CreateCustomFieldOptionFunc: func(contextMoqParam context.Context, s1 string, s2 string) (string, error) {
+//				panic("mock out the CreateCustomFieldOption method")
+//			},
+//			CreateLabelFunc: func(contextMoqParam context.Context, s1 string, s2 string) (*trello.Label, error) {
+//				panic("mock out the CreateLabel method")
+//			},
+//			GetBoardCardsFunc: func(contextMoqParam context.Context) ([]*trello.Card, error) {
+//				panic("mock out the GetBoardCards method")
+//			},
+//			GetBoardsFunc: func(contextMoqParam context.Context) (map[string]*trello.Board, error) {
 //				panic("mock out the GetBoards method")
 //			},
+//			GetCardFunc: func(contextMoqParam context.Context, s string) (*trello.Card, error) {
+//				panic("mock out the GetCard method")
+//			},
 //			GetConfigFunc: func() *trello.Config {
 //				panic("mock out the GetConfig method")
 //			},
-//			GetLabelsFunc: func() (map[string]*trello.Label, error) {
+//			GetCustomFieldsFunc: func(contextMoqParam context.Context) (map[string]*trello.CustomField, error) {
+//				panic("mock out the GetCustomFields method")
+//			},
+//			GetEpicCardsFunc: func(contextMoqParam context.Context) ([]*trello.Card, error) {
+//				panic("mock out the GetEpicCards method")
+//			},
+//			GetJiraCardsFunc: func(contextMoqParam context.Context) ([]*trello.Card, error) {
+//				panic("mock out the GetJiraCards method")
+//			},
+//			GetLabelsFunc: func(contextMoqParam context.Context) (map[string]*trello.Label, error) {
 //				panic("mock out the GetLabels method")
 //			},
-//			GetListsFunc: func() (map[string]*trello.List, error) {
+//			GetListsFunc: func(contextMoqParam context.Context) (map[string]*trello.List, error) {
 //				panic("mock out the GetLists method")
 //			},
-//			GetUserJiraCardsFunc: func() ([]*trello.Card, error) {
+//			GetMemberJiraCardsFunc: func(contextMoqParam context.Context, s string) ([]*trello.Card, error) {
+//				panic("mock out the GetMemberJiraCards method")
+//			},
+//			GetMembersFunc: func(contextMoqParam context.Context) (map[string]*trello.Member, error) {
+//				panic("mock out the GetMembers method")
+//			},
+//			GetUserJiraCardsFunc: func(contextMoqParam context.Context) ([]*trello.Card, error) {
 //				panic("mock out the GetUserJiraCards method")
 //			},
-//			MoveCardToListFunc: func(s1 string, s2 string) error {
+//			MoveCardToListFunc: func(contextMoqParam context.Context, s1 string, s2 string) error {
 //				panic("mock out the MoveCardToList method")
 //			},
-//			SetBoardFunc: func() error {
+//			RegisterWebhookFunc: func(contextMoqParam context.Context, s string) error {
+//				panic("mock out the RegisterWebhook method")
+//			},
+//			SetBoardFunc: func(contextMoqParam context.Context) error {
 //				panic("mock out the SetBoard method")
 //			},
-//			UpdateCardLabelsFunc: func(s1 string, s2 string) error {
+//			SetCardCustomFieldFunc: func(contextMoqParam context.Context, s1 string, customField *trello.CustomField, s2 string) error {
+//				panic("mock out the SetCardCustomField method")
+//			},
+//			SetCheckItemStateFunc: func(contextMoqParam context.Context, s1 string, s2 string, b bool) error {
+//				panic("mock out the SetCheckItemState method")
+//			},
+//			UnarchiveCardFunc: func(contextMoqParam context.Context, s string) error {
+//				panic("mock out the UnarchiveCard method")
+//			},
+//			UpdateCardDescFunc: func(contextMoqParam context.Context, s1 string, s2 string) error {
+//				panic("mock out the UpdateCardDesc method")
+//			},
+//			UpdateCardLabelsFunc: func(contextMoqParam context.Context, s1 string, s2 string) error {
 //				panic("mock out the UpdateCardLabels method")
 //			},
+//			UpdateCardMembersFunc: func(contextMoqParam context.Context, s1 string, s2 string) error {
+//				panic("mock out the UpdateCardMembers method")
+//			},
+//			UpdateCardNameFunc: func(contextMoqParam context.Context, s1 string, s2 string) error {
+//				panic("mock out the UpdateCardName method")
+//			},
 //		}
 //
 //		// use mockedTrelloConnector in code that requires TrelloConnector
@@ -58,114 +116,397 @@ var _ TrelloConnector = &TrelloConnectorMock{}
 //
 //	}
 type TrelloConnectorMock struct {
+	// AddCheckItemFunc mocks the AddCheckItem method.
+	AddCheckItemFunc func(contextMoqParam context.Context, card *trello.Card, s string) error
+
 	// ArchiveAllCardsInListFunc mocks the ArchiveAllCardsInList method.
-	ArchiveAllCardsInListFunc func(s string) error
+	ArchiveAllCardsInListFunc func(contextMoqParam context.Context, s string) ([]*trello.Card, error)
+
+	// ArchiveCardFunc mocks the ArchiveCard method.
+	ArchiveCardFunc func(contextMoqParam context.Context, s string) error
 
 	// ConnectFunc mocks the Connect method.
-	ConnectFunc func() error
+	ConnectFunc func(contextMoqParam context.Context) error
 
 	// CreateCardFunc mocks the CreateCard method.
-	CreateCardFunc func(card *trello.Card) error
+	CreateCardFunc func(contextMoqParam context.Context, card *trello.Card) error
+
+	// CreateCustomFieldFunc mocks the CreateCustomField method.
+	CreateCustomFieldFunc func(contextMoqParam context.Context, s1 string, s2 string) (*trello.CustomField, error)
+
+	// CreateCustomFieldOptionFunc mocks the CreateCustomFieldOption method.
+	CreateCustomFieldOptionFunc func(contextMoqParam context.Context, s1 string, s2 string) (string, error)
+
+	// CreateLabelFunc mocks the CreateLabel method.
+	CreateLabelFunc func(contextMoqParam context.Context, s1 string, s2 string) (*trello.Label, error)
+
+	// GetBoardCardsFunc mocks the GetBoardCards method.
+	GetBoardCardsFunc func(contextMoqParam context.Context) ([]*trello.Card, error)
 
 	// GetBoardsFunc mocks the GetBoards method.
-	GetBoardsFunc func() (map[string]*trello.Board, error)
+	GetBoardsFunc func(contextMoqParam context.Context) (map[string]*trello.Board, error)
+
+	// GetCardFunc mocks the GetCard method.
+	GetCardFunc func(contextMoqParam context.Context, s string) (*trello.Card, error)
 
 	// GetConfigFunc mocks the GetConfig method.
 	GetConfigFunc func() *trello.Config
 
+	// GetCustomFieldsFunc mocks the GetCustomFields method.
+	GetCustomFieldsFunc func(contextMoqParam context.Context) (map[string]*trello.CustomField, error)
+
+	// GetEpicCardsFunc mocks the GetEpicCards method.
+	GetEpicCardsFunc func(contextMoqParam context.Context) ([]*trello.Card, error)
+
+	// GetJiraCardsFunc mocks the GetJiraCards method.
+	GetJiraCardsFunc func(contextMoqParam context.Context) ([]*trello.Card, error)
+
 	// GetLabelsFunc mocks the GetLabels method.
-	GetLabelsFunc func() (map[string]*trello.Label, error)
+	GetLabelsFunc func(contextMoqParam context.Context) (map[string]*trello.Label, error)
 
 	// GetListsFunc mocks the GetLists method.
-	GetListsFunc func() (map[string]*trello.List, error)
+	GetListsFunc func(contextMoqParam context.Context) (map[string]*trello.List, error)
+
+	// GetMemberJiraCardsFunc mocks the GetMemberJiraCards method.
+	GetMemberJiraCardsFunc func(contextMoqParam context.Context, s string) ([]*trello.Card, error)
+
+	// GetMembersFunc mocks the GetMembers method.
+	GetMembersFunc func(contextMoqParam context.Context) (map[string]*trello.Member, error)
 
 	// GetUserJiraCardsFunc mocks the GetUserJiraCards method.
-	GetUserJiraCardsFunc func() ([]*trello.Card, error)
+	GetUserJiraCardsFunc func(contextMoqParam context.Context) ([]*trello.Card, error)
 
 	// MoveCardToListFunc mocks the MoveCardToList method.
-	MoveCardToListFunc func(s1 string, s2 string) error
+	MoveCardToListFunc func(contextMoqParam context.Context, s1 string, s2 string) error
+
+	// RegisterWebhookFunc mocks the RegisterWebhook method.
+	RegisterWebhookFunc func(contextMoqParam context.Context, s string) error
 
 	// SetBoardFunc mocks the SetBoard method.
-	SetBoardFunc func() error
+	SetBoardFunc func(contextMoqParam context.Context) error
+
+	// SetCardCustomFieldFunc mocks the SetCardCustomField method.
+	SetCardCustomFieldFunc func(contextMoqParam context.Context, s1 string, customField *trello.CustomField, s2 string) error
+
+	// SetCheckItemStateFunc mocks the SetCheckItemState method.
+	SetCheckItemStateFunc func(contextMoqParam context.Context, s1 string, s2 string, b bool) error
+
+	// UnarchiveCardFunc mocks the UnarchiveCard method.
+	UnarchiveCardFunc func(contextMoqParam context.Context, s string) error
+
+	// UpdateCardDescFunc mocks the UpdateCardDesc method.
+	UpdateCardDescFunc func(contextMoqParam context.Context, s1 string, s2 string) error
 
 	// UpdateCardLabelsFunc mocks the UpdateCardLabels method.
-	UpdateCardLabelsFunc func(s1 string, s2 string) error
+	UpdateCardLabelsFunc func(contextMoqParam context.Context, s1 string, s2 string) error
+
+	// UpdateCardMembersFunc mocks the UpdateCardMembers method.
+	UpdateCardMembersFunc func(contextMoqParam context.Context, s1 string, s2 string) error
+
+	// UpdateCardNameFunc mocks the UpdateCardName method.
+	UpdateCardNameFunc func(contextMoqParam context.Context, s1 string, s2 string) error
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// AddCheckItem holds details about calls to the AddCheckItem method.
+		AddCheckItem []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// Card is the card argument value.
+			Card *trello.Card
+			// S is the s argument value.
+			S string
+		}
 		// ArchiveAllCardsInList holds details about calls to the ArchiveAllCardsInList method.
 		ArchiveAllCardsInList []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// S is the s argument value.
+			S string
+		}
+		// ArchiveCard holds details about calls to the ArchiveCard method.
+		ArchiveCard []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
 			// S is the s argument value.
 			S string
 		}
 		// Connect holds details about calls to the Connect method.
 		Connect []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
 		}
 		// CreateCard holds details about calls to the CreateCard method.
 		CreateCard []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
 			// Card is the card argument value.
 			Card *trello.Card
 		}
+		// CreateCustomField holds details about calls to the CreateCustomField method.
+		CreateCustomField []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// S1 is the s1 argument value.
+			S1 string
+			// S2 is the s2 argument value.
+			S2 string
+		}
+		// CreateCustomFieldOption holds details about calls to the CreateCustomFieldOption method.
+		CreateCustomFieldOption []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// S1 is the s1 argument value.
+			S1 string
+			// S2 is the s2 argument value.
+			S2 string
+		}
+		// CreateLabel holds details about calls to the CreateLabel method.
+		CreateLabel []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// S1 is the s1 argument value.
+			S1 string
+			// S2 is the s2 argument value.
+			S2 string
+		}
+		// GetBoardCards holds details about calls to the GetBoardCards method.
+		GetBoardCards []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+		}
 		// GetBoards holds details about calls to the GetBoards method.
 		GetBoards []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+		}
+		// GetCard holds details about calls to the GetCard method.
+		GetCard []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// S is the s argument value.
+			S string
 		}
 		// GetConfig holds details about calls to the GetConfig method.
 		GetConfig []struct {
 		}
+		// GetCustomFields holds details about calls to the GetCustomFields method.
+		GetCustomFields []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+		}
+		// GetEpicCards holds details about calls to the GetEpicCards method.
+		GetEpicCards []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+		}
+		// GetJiraCards holds details about calls to the GetJiraCards method.
+		GetJiraCards []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+		}
 		// GetLabels holds details about calls to the GetLabels method.
 		GetLabels []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
 		}
 		// GetLists holds details about calls to the GetLists method.
 		GetLists []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+		}
+		// GetMemberJiraCards holds details about calls to the GetMemberJiraCards method.
+		GetMemberJiraCards []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// S is the s argument value.
+			S string
+		}
+		// GetMembers holds details about calls to the GetMembers method.
+		GetMembers []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
 		}
 		// GetUserJiraCards holds details about calls to the GetUserJiraCards method.
 		GetUserJiraCards []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
 		}
 		// MoveCardToList holds details about calls to the MoveCardToList method.
 		MoveCardToList []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
 			// S1 is the s1 argument value.
 			S1 string
 			// S2 is the s2 argument value.
 			S2 string
 		}
+		// RegisterWebhook holds details about calls to the RegisterWebhook method.
+		RegisterWebhook []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// S is the s argument value.
+			S string
+		}
 		// SetBoard holds details about calls to the SetBoard method.
 		SetBoard []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+		}
+		// SetCardCustomField holds details about calls to the SetCardCustomField method.
+		SetCardCustomField []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// S1 is the s1 argument value.
+			S1 string
+			// CustomField is the customField argument value.
+			CustomField *trello.CustomField
+			// S2 is the s2 argument value.
+			S2 string
+		}
+		// SetCheckItemState holds details about calls to the SetCheckItemState method.
+		SetCheckItemState []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// S1 is the s1 argument value.
+			S1 string
+			// S2 is the s2 argument value.
+			S2 string
+			// B is the b argument value.
+			B bool
+		}
+		// UnarchiveCard holds details about calls to the UnarchiveCard method.
+		UnarchiveCard []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// S is the s argument value.
+			S string
+		}
+		// UpdateCardDesc holds details about calls to the UpdateCardDesc method.
+		UpdateCardDesc []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// S1 is the s1 argument value.
+			S1 string
+			// S2 is the s2 argument value.
+			S2 string
 		}
 		// UpdateCardLabels holds details about calls to the UpdateCardLabels method.
 		UpdateCardLabels []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// S1 is the s1 argument value.
+			S1 string
+			// S2 is the s2 argument value.
+			S2 string
+		}
+		// UpdateCardMembers holds details about calls to the UpdateCardMembers method.
+		UpdateCardMembers []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// S1 is the s1 argument value.
+			S1 string
+			// S2 is the s2 argument value.
+			S2 string
+		}
+		// UpdateCardName holds details about calls to the UpdateCardName method.
+		UpdateCardName []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
 			// S1 is the s1 argument value.
 			S1 string
 			// S2 is the s2 argument value.
 			S2 string
 		}
 	}
-	lockArchiveAllCardsInList sync.RWMutex
-	lockConnect               sync.RWMutex
-	lockCreateCard            sync.RWMutex
-	lockGetBoards             sync.RWMutex
-	lockGetConfig             sync.RWMutex
-	lockGetLabels             sync.RWMutex
-	lockGetLists              sync.RWMutex
-	lockGetUserJiraCards      sync.RWMutex
-	lockMoveCardToList        sync.RWMutex
-	lockSetBoard              sync.RWMutex
-	lockUpdateCardLabels      sync.RWMutex
+	lockAddCheckItem            sync.RWMutex
+	lockArchiveAllCardsInList   sync.RWMutex
+	lockArchiveCard             sync.RWMutex
+	lockConnect                 sync.RWMutex
+	lockCreateCard              sync.RWMutex
+	lockCreateCustomField       sync.RWMutex
+	lockCreateCustomFieldOption sync.RWMutex
+	lockCreateLabel             sync.RWMutex
+	lockGetBoardCards           sync.RWMutex
+	lockGetBoards               sync.RWMutex
+	lockGetCard                 sync.RWMutex
+	lockGetConfig               sync.RWMutex
+	lockGetCustomFields         sync.RWMutex
+	lockGetEpicCards            sync.RWMutex
+	lockGetJiraCards            sync.RWMutex
+	lockGetLabels               sync.RWMutex
+	lockGetLists                sync.RWMutex
+	lockGetMemberJiraCards      sync.RWMutex
+	lockGetMembers              sync.RWMutex
+	lockGetUserJiraCards        sync.RWMutex
+	lockMoveCardToList          sync.RWMutex
+	lockRegisterWebhook         sync.RWMutex
+	lockSetBoard                sync.RWMutex
+	lockSetCardCustomField      sync.RWMutex
+	lockSetCheckItemState       sync.RWMutex
+	lockUnarchiveCard           sync.RWMutex
+	lockUpdateCardDesc          sync.RWMutex
+	lockUpdateCardLabels        sync.RWMutex
+	lockUpdateCardMembers       sync.RWMutex
+	lockUpdateCardName          sync.RWMutex
+}
+
+// AddCheckItem calls AddCheckItemFunc.
+func (mock *TrelloConnectorMock) AddCheckItem(contextMoqParam context.Context, card *trello.Card, s string) error {
+	if mock.AddCheckItemFunc == nil {
+		panic("TrelloConnectorMock.AddCheckItemFunc: method is nil but TrelloConnector.AddCheckItem was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+		Card            *trello.Card
+		S               string
+	}{
+		ContextMoqParam: contextMoqParam,
+		Card:            card,
+		S:               s,
+	}
+	mock.lockAddCheckItem.Lock()
+	mock.calls.AddCheckItem = append(mock.calls.AddCheckItem, callInfo)
+	mock.lockAddCheckItem.Unlock()
+	return mock.AddCheckItemFunc(contextMoqParam, card, s)
+}
+
+// AddCheckItemCalls gets all the calls that were made to AddCheckItem.
+// Check the length with:
+//
+//	len(mockedTrelloConnector.AddCheckItemCalls())
+func (mock *TrelloConnectorMock) AddCheckItemCalls() []struct {
+	ContextMoqParam context.Context
+	Card            *trello.Card
+	S               string
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+		Card            *trello.Card
+		S               string
+	}
+	mock.lockAddCheckItem.RLock()
+	calls = mock.calls.AddCheckItem
+	mock.lockAddCheckItem.RUnlock()
+	return calls
 }
 
 // ArchiveAllCardsInList calls ArchiveAllCardsInListFunc.
-func (mock *TrelloConnectorMock) ArchiveAllCardsInList(s string) error {
+func (mock *TrelloConnectorMock) ArchiveAllCardsInList(contextMoqParam context.Context, s string) ([]*trello.Card, error) {
 	if mock.ArchiveAllCardsInListFunc == nil {
 		panic("TrelloConnectorMock.ArchiveAllCardsInListFunc: method is nil but TrelloConnector.ArchiveAllCardsInList was just called")
 	}
 	callInfo := struct {
-		S string
+		ContextMoqParam context.Context
+		S               string
 	}{
-		S: s,
+		ContextMoqParam: contextMoqParam,
+		S:               s,
 	}
 	mock.lockArchiveAllCardsInList.Lock()
 	mock.calls.ArchiveAllCardsInList = append(mock.calls.ArchiveAllCardsInList, callInfo)
 	mock.lockArchiveAllCardsInList.Unlock()
-	return mock.ArchiveAllCardsInListFunc(s)
+	return mock.ArchiveAllCardsInListFunc(contextMoqParam, s)
 }
 
 // ArchiveAllCardsInListCalls gets all the calls that were made to ArchiveAllCardsInList.
@@ -173,10 +514,12 @@ func (mock *TrelloConnectorMock) ArchiveAllCardsInList(s string) error {
 //
 //	len(mockedTrelloConnector.ArchiveAllCardsInListCalls())
 func (mock *TrelloConnectorMock) ArchiveAllCardsInListCalls() []struct {
-	S string
+	ContextMoqParam context.Context
+	S               string
 } {
 	var calls []struct {
-		S string
+		ContextMoqParam context.Context
+		S               string
 	}
 	mock.lockArchiveAllCardsInList.RLock()
 	calls = mock.calls.ArchiveAllCardsInList
@@ -184,17 +527,56 @@ func (mock *TrelloConnectorMock) ArchiveAllCardsInListCalls() []struct {
 	return calls
 }
 
+// ArchiveCard calls ArchiveCardFunc.
+func (mock *TrelloConnectorMock) ArchiveCard(contextMoqParam context.Context, s string) error {
+	if mock.ArchiveCardFunc == nil {
+		panic("TrelloConnectorMock.ArchiveCardFunc: method is nil but TrelloConnector.ArchiveCard was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+		S               string
+	}{
+		ContextMoqParam: contextMoqParam,
+		S:               s,
+	}
+	mock.lockArchiveCard.Lock()
+	mock.calls.ArchiveCard = append(mock.calls.ArchiveCard, callInfo)
+	mock.lockArchiveCard.Unlock()
+	return mock.ArchiveCardFunc(contextMoqParam, s)
+}
+
+// ArchiveCardCalls gets all the calls that were made to ArchiveCard.
+// Check the length with:
+//
+//	len(mockedTrelloConnector.ArchiveCardCalls())
+func (mock *TrelloConnectorMock) ArchiveCardCalls() []struct {
+	ContextMoqParam context.Context
+	S               string
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+		S               string
+	}
+	mock.lockArchiveCard.RLock()
+	calls = mock.calls.ArchiveCard
+	mock.lockArchiveCard.RUnlock()
+	return calls
+}
+
 // Connect calls ConnectFunc.
-func (mock *TrelloConnectorMock) Connect() error {
+func (mock *TrelloConnectorMock) Connect(contextMoqParam context.Context) error {
 	if mock.ConnectFunc == nil {
 		panic("TrelloConnectorMock.ConnectFunc: method is nil but TrelloConnector.Connect was just called")
 	}
 	callInfo := struct {
-	}{}
+		ContextMoqParam context.Context
+	}{
+		ContextMoqParam: contextMoqParam,
+	}
 	mock.lockConnect.Lock()
 	mock.calls.Connect = append(mock.calls.Connect, callInfo)
 	mock.lockConnect.Unlock()
-	return mock.ConnectFunc()
+	return mock.ConnectFunc(contextMoqParam)
 }
 
 // ConnectCalls gets all the calls that were made to Connect.
@@ -202,8 +584,10 @@ func (mock *TrelloConnectorMock) Connect() error {
 //
 //	len(mockedTrelloConnector.ConnectCalls())
 func (mock *TrelloConnectorMock) ConnectCalls() []struct {
+	ContextMoqParam context.Context
 } {
 	var calls []struct {
+		ContextMoqParam context.Context
 	}
 	mock.lockConnect.RLock()
 	calls = mock.calls.Connect
@@ -212,19 +596,21 @@ func (mock *TrelloConnectorMock) ConnectCalls() []struct {
 }
 
 // CreateCard calls CreateCardFunc.
-func (mock *TrelloConnectorMock) CreateCard(card *trello.Card) error {
+func (mock *TrelloConnectorMock) CreateCard(contextMoqParam context.Context, card *trello.Card) error {
 	if mock.CreateCardFunc == nil {
 		panic("TrelloConnectorMock.CreateCardFunc: method is nil but TrelloConnector.CreateCard was just called")
 	}
 	callInfo := struct {
-		Card *trello.Card
+		ContextMoqParam context.Context
+		Card            *trello.Card
 	}{
-		Card: card,
+		ContextMoqParam: contextMoqParam,
+		Card:            card,
 	}
 	mock.lockCreateCard.Lock()
 	mock.calls.CreateCard = append(mock.calls.CreateCard, callInfo)
 	mock.lockCreateCard.Unlock()
-	return mock.CreateCardFunc(card)
+	return mock.CreateCardFunc(contextMoqParam, card)
 }
 
 // CreateCardCalls gets all the calls that were made to CreateCard.
@@ -232,10 +618,12 @@ func (mock *TrelloConnectorMock) CreateCard(card *trello.Card) error {
 //
 //	len(mockedTrelloConnector.CreateCardCalls())
 func (mock *TrelloConnectorMock) CreateCardCalls() []struct {
-	Card *trello.Card
+	ContextMoqParam context.Context
+	Card            *trello.Card
 } {
 	var calls []struct {
-		Card *trello.Card
+		ContextMoqParam context.Context
+		Card            *trello.Card
 	}
 	mock.lockCreateCard.RLock()
 	calls = mock.calls.CreateCard
@@ -243,17 +631,172 @@ func (mock *TrelloConnectorMock) CreateCardCalls() []struct {
 	return calls
 }
 
+// CreateCustomField calls CreateCustomFieldFunc.
+func (mock *TrelloConnectorMock) CreateCustomField(contextMoqParam context.Context, s1 string, s2 string) (*trello.CustomField, error) {
+	if mock.CreateCustomFieldFunc == nil {
+		panic("TrelloConnectorMock.CreateCustomFieldFunc: method is nil but TrelloConnector.CreateCustomField was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+		S1              string
+		S2              string
+	}{
+		ContextMoqParam: contextMoqParam,
+		S1:              s1,
+		S2:              s2,
+	}
+	mock.lockCreateCustomField.Lock()
+	mock.calls.CreateCustomField = append(mock.calls.CreateCustomField, callInfo)
+	mock.lockCreateCustomField.Unlock()
+	return mock.CreateCustomFieldFunc(contextMoqParam, s1, s2)
+}
+
+// CreateCustomFieldCalls gets all the calls that were made to CreateCustomField.
+// Check the length with:
+//
+//	len(mockedTrelloConnector.CreateCustomFieldCalls())
+func (mock *TrelloConnectorMock) CreateCustomFieldCalls() []struct {
+	ContextMoqParam context.Context
+	S1              string
+	S2              string
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+		S1              string
+		S2              string
+	}
+	mock.lockCreateCustomField.RLock()
+	calls = mock.calls.CreateCustomField
+	mock.lockCreateCustomField.RUnlock()
+	return calls
+}
+
+// CreateCustomFieldOption calls CreateCustomFieldOptionFunc.
+func (mock *TrelloConnectorMock) CreateCustomFieldOption(contextMoqParam context.Context, s1 string, s2 string) (string, error) {
+	if mock.CreateCustomFieldOptionFunc == nil {
+		panic("TrelloConnectorMock.CreateCustomFieldOptionFunc: method is nil but TrelloConnector.CreateCustomFieldOption was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+		S1              string
+		S2              string
+	}{
+		ContextMoqParam: contextMoqParam,
+		S1:              s1,
+		S2:              s2,
+	}
+	mock.lockCreateCustomFieldOption.Lock()
+	mock.calls.CreateCustomFieldOption = append(mock.calls.CreateCustomFieldOption, callInfo)
+	mock.lockCreateCustomFieldOption.Unlock()
+	return mock.CreateCustomFieldOptionFunc(contextMoqParam, s1, s2)
+}
+
+// CreateCustomFieldOptionCalls gets all the calls that were made to CreateCustomFieldOption.
+// Check the length with:
+//
+//	len(mockedTrelloConnector.CreateCustomFieldOptionCalls())
+func (mock *TrelloConnectorMock) CreateCustomFieldOptionCalls() []struct {
+	ContextMoqParam context.Context
+	S1              string
+	S2              string
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+		S1              string
+		S2              string
+	}
+	mock.lockCreateCustomFieldOption.RLock()
+	calls = mock.calls.CreateCustomFieldOption
+	mock.lockCreateCustomFieldOption.RUnlock()
+	return calls
+}
+
+// CreateLabel calls CreateLabelFunc.
+func (mock *TrelloConnectorMock) CreateLabel(contextMoqParam context.Context, s1 string, s2 string) (*trello.Label, error) {
+	if mock.CreateLabelFunc == nil {
+		panic("TrelloConnectorMock.CreateLabelFunc: method is nil but TrelloConnector.CreateLabel was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+		S1              string
+		S2              string
+	}{
+		ContextMoqParam: contextMoqParam,
+		S1:              s1,
+		S2:              s2,
+	}
+	mock.lockCreateLabel.Lock()
+	mock.calls.CreateLabel = append(mock.calls.CreateLabel, callInfo)
+	mock.lockCreateLabel.Unlock()
+	return mock.CreateLabelFunc(contextMoqParam, s1, s2)
+}
+
+// CreateLabelCalls gets all the calls that were made to CreateLabel.
+// Check the length with:
+//
+//	len(mockedTrelloConnector.CreateLabelCalls())
+func (mock *TrelloConnectorMock) CreateLabelCalls() []struct {
+	ContextMoqParam context.Context
+	S1              string
+	S2              string
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+		S1              string
+		S2              string
+	}
+	mock.lockCreateLabel.RLock()
+	calls = mock.calls.CreateLabel
+	mock.lockCreateLabel.RUnlock()
+	return calls
+}
+
+// GetBoardCards calls GetBoardCardsFunc.
+func (mock *TrelloConnectorMock) GetBoardCards(contextMoqParam context.Context) ([]*trello.Card, error) {
+	if mock.GetBoardCardsFunc == nil {
+		panic("TrelloConnectorMock.GetBoardCardsFunc: method is nil but TrelloConnector.GetBoardCards was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+	}{
+		ContextMoqParam: contextMoqParam,
+	}
+	mock.lockGetBoardCards.Lock()
+	mock.calls.GetBoardCards = append(mock.calls.GetBoardCards, callInfo)
+	mock.lockGetBoardCards.Unlock()
+	return mock.GetBoardCardsFunc(contextMoqParam)
+}
+
+// GetBoardCardsCalls gets all the calls that were made to GetBoardCards.
+// Check the length with:
+//
+//	len(mockedTrelloConnector.GetBoardCardsCalls())
+func (mock *TrelloConnectorMock) GetBoardCardsCalls() []struct {
+	ContextMoqParam context.Context
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+	}
+	mock.lockGetBoardCards.RLock()
+	calls = mock.calls.GetBoardCards
+	mock.lockGetBoardCards.RUnlock()
+	return calls
+}
+
 // GetBoards calls GetBoardsFunc.
-func (mock *TrelloConnectorMock) GetBoards() (map[string]*trello.Board, error) {
+func (mock *TrelloConnectorMock) GetBoards(contextMoqParam context.Context) (map[string]*trello.Board, error) {
 	if mock.GetBoardsFunc == nil {
 		panic("TrelloConnectorMock.GetBoardsFunc: method is nil but TrelloConnector.GetBoards was just called")
 	}
 	callInfo := struct {
-	}{}
+		ContextMoqParam context.Context
+	}{
+		ContextMoqParam: contextMoqParam,
+	}
 	mock.lockGetBoards.Lock()
 	mock.calls.GetBoards = append(mock.calls.GetBoards, callInfo)
 	mock.lockGetBoards.Unlock()
-	return mock.GetBoardsFunc()
+	return mock.GetBoardsFunc(contextMoqParam)
 }
 
 // GetBoardsCalls gets all the calls that were made to GetBoards.
@@ -261,8 +804,10 @@ func (mock *TrelloConnectorMock) GetBoards() (map[string]*trello.Board, error) {
 //
 //	len(mockedTrelloConnector.GetBoardsCalls())
 func (mock *TrelloConnectorMock) GetBoardsCalls() []struct {
+	ContextMoqParam context.Context
 } {
 	var calls []struct {
+		ContextMoqParam context.Context
 	}
 	mock.lockGetBoards.RLock()
 	calls = mock.calls.GetBoards
@@ -270,6 +815,42 @@ func (mock *TrelloConnectorMock) GetBoardsCalls() []struct {
 	return calls
 }
 
+// GetCard calls GetCardFunc.
+func (mock *TrelloConnectorMock) GetCard(contextMoqParam context.Context, s string) (*trello.Card, error) {
+	if mock.GetCardFunc == nil {
+		panic("TrelloConnectorMock.GetCardFunc: method is nil but TrelloConnector.GetCard was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+		S               string
+	}{
+		ContextMoqParam: contextMoqParam,
+		S:               s,
+	}
+	mock.lockGetCard.Lock()
+	mock.calls.GetCard = append(mock.calls.GetCard, callInfo)
+	mock.lockGetCard.Unlock()
+	return mock.GetCardFunc(contextMoqParam, s)
+}
+
+// GetCardCalls gets all the calls that were made to GetCard.
+// Check the length with:
+//
+//	len(mockedTrelloConnector.GetCardCalls())
+func (mock *TrelloConnectorMock) GetCardCalls() []struct {
+	ContextMoqParam context.Context
+	S               string
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+		S               string
+	}
+	mock.lockGetCard.RLock()
+	calls = mock.calls.GetCard
+	mock.lockGetCard.RUnlock()
+	return calls
+}
+
 // GetConfig calls GetConfigFunc.
 func (mock *TrelloConnectorMock) GetConfig() *trello.Config {
 	if mock.GetConfigFunc == nil {
@@ -297,17 +878,116 @@ func (mock *TrelloConnectorMock) GetConfigCalls() []struct {
 	return calls
 }
 
+// GetCustomFields calls GetCustomFieldsFunc.
+func (mock *TrelloConnectorMock) GetCustomFields(contextMoqParam context.Context) (map[string]*trello.CustomField, error) {
+	if mock.GetCustomFieldsFunc == nil {
+		panic("TrelloConnectorMock.GetCustomFieldsFunc: method is nil but TrelloConnector.GetCustomFields was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+	}{
+		ContextMoqParam: contextMoqParam,
+	}
+	mock.lockGetCustomFields.Lock()
+	mock.calls.GetCustomFields = append(mock.calls.GetCustomFields, callInfo)
+	mock.lockGetCustomFields.Unlock()
+	return mock.GetCustomFieldsFunc(contextMoqParam)
+}
+
+// GetCustomFieldsCalls gets all the calls that were made to GetCustomFields.
+// Check the length with:
+//
+//	len(mockedTrelloConnector.GetCustomFieldsCalls())
+func (mock *TrelloConnectorMock) GetCustomFieldsCalls() []struct {
+	ContextMoqParam context.Context
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+	}
+	mock.lockGetCustomFields.RLock()
+	calls = mock.calls.GetCustomFields
+	mock.lockGetCustomFields.RUnlock()
+	return calls
+}
+
+// GetEpicCards calls GetEpicCardsFunc.
+func (mock *TrelloConnectorMock) GetEpicCards(contextMoqParam context.Context) ([]*trello.Card, error) {
+	if mock.GetEpicCardsFunc == nil {
+		panic("TrelloConnectorMock.GetEpicCardsFunc: method is nil but TrelloConnector.GetEpicCards was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+	}{
+		ContextMoqParam: contextMoqParam,
+	}
+	mock.lockGetEpicCards.Lock()
+	mock.calls.GetEpicCards = append(mock.calls.GetEpicCards, callInfo)
+	mock.lockGetEpicCards.Unlock()
+	return mock.GetEpicCardsFunc(contextMoqParam)
+}
+
+// GetEpicCardsCalls gets all the calls that were made to GetEpicCards.
+// Check the length with:
+//
+//	len(mockedTrelloConnector.GetEpicCardsCalls())
+func (mock *TrelloConnectorMock) GetEpicCardsCalls() []struct {
+	ContextMoqParam context.Context
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+	}
+	mock.lockGetEpicCards.RLock()
+	calls = mock.calls.GetEpicCards
+	mock.lockGetEpicCards.RUnlock()
+	return calls
+}
+
+// GetJiraCards calls GetJiraCardsFunc.
+func (mock *TrelloConnectorMock) GetJiraCards(contextMoqParam context.Context) ([]*trello.Card, error) {
+	if mock.GetJiraCardsFunc == nil {
+		panic("TrelloConnectorMock.GetJiraCardsFunc: method is nil but TrelloConnector.GetJiraCards was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+	}{
+		ContextMoqParam: contextMoqParam,
+	}
+	mock.lockGetJiraCards.Lock()
+	mock.calls.GetJiraCards = append(mock.calls.GetJiraCards, callInfo)
+	mock.lockGetJiraCards.Unlock()
+	return mock.GetJiraCardsFunc(contextMoqParam)
+}
+
+// GetJiraCardsCalls gets all the calls that were made to GetJiraCards.
+// Check the length with:
+//
+//	len(mockedTrelloConnector.GetJiraCardsCalls())
+func (mock *TrelloConnectorMock) GetJiraCardsCalls() []struct {
+	ContextMoqParam context.Context
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+	}
+	mock.lockGetJiraCards.RLock()
+	calls = mock.calls.GetJiraCards
+	mock.lockGetJiraCards.RUnlock()
+	return calls
+}
+
 // GetLabels calls GetLabelsFunc.
-func (mock *TrelloConnectorMock) GetLabels() (map[string]*trello.Label, error) {
+func (mock *TrelloConnectorMock) GetLabels(contextMoqParam context.Context) (map[string]*trello.Label, error) {
 	if mock.GetLabelsFunc == nil {
 		panic("TrelloConnectorMock.GetLabelsFunc: method is nil but TrelloConnector.GetLabels was just called")
 	}
 	callInfo := struct {
-	}{}
+		ContextMoqParam context.Context
+	}{
+		ContextMoqParam: contextMoqParam,
+	}
 	mock.lockGetLabels.Lock()
 	mock.calls.GetLabels = append(mock.calls.GetLabels, callInfo)
 	mock.lockGetLabels.Unlock()
-	return mock.GetLabelsFunc()
+	return mock.GetLabelsFunc(contextMoqParam)
 }
 
 // GetLabelsCalls gets all the calls that were made to GetLabels.
@@ -315,8 +995,10 @@ func (mock *TrelloConnectorMock) GetLabels() (map[string]*trello.Label, error) {
 //
 //	len(mockedTrelloConnector.GetLabelsCalls())
 func (mock *TrelloConnectorMock) GetLabelsCalls() []struct {
+	ContextMoqParam context.Context
 } {
 	var calls []struct {
+		ContextMoqParam context.Context
 	}
 	mock.lockGetLabels.RLock()
 	calls = mock.calls.GetLabels
@@ -325,16 +1007,19 @@ func (mock *TrelloConnectorMock) GetLabelsCalls() []struct {
 }
 
 // GetLists calls GetListsFunc.
-func (mock *TrelloConnectorMock) GetLists() (map[string]*trello.List, error) {
+func (mock *TrelloConnectorMock) GetLists(contextMoqParam context.Context) (map[string]*trello.List, error) {
 	if mock.GetListsFunc == nil {
 		panic("TrelloConnectorMock.GetListsFunc: method is nil but TrelloConnector.GetLists was just called")
 	}
 	callInfo := struct {
-	}{}
+		ContextMoqParam context.Context
+	}{
+		ContextMoqParam: contextMoqParam,
+	}
 	mock.lockGetLists.Lock()
 	mock.calls.GetLists = append(mock.calls.GetLists, callInfo)
 	mock.lockGetLists.Unlock()
-	return mock.GetListsFunc()
+	return mock.GetListsFunc(contextMoqParam)
 }
 
 // GetListsCalls gets all the calls that were made to GetLists.
@@ -342,8 +1027,10 @@ func (mock *TrelloConnectorMock) GetLists() (map[string]*trello.List, error) {
 //
 //	len(mockedTrelloConnector.GetListsCalls())
 func (mock *TrelloConnectorMock) GetListsCalls() []struct {
+	ContextMoqParam context.Context
 } {
 	var calls []struct {
+		ContextMoqParam context.Context
 	}
 	mock.lockGetLists.RLock()
 	calls = mock.calls.GetLists
@@ -351,17 +1038,88 @@ func (mock *TrelloConnectorMock) GetListsCalls() []struct {
 	return calls
 }
 
+// GetMemberJiraCards calls GetMemberJiraCardsFunc.
+func (mock *TrelloConnectorMock) GetMemberJiraCards(contextMoqParam context.Context, s string) ([]*trello.Card, error) {
+	if mock.GetMemberJiraCardsFunc == nil {
+		panic("TrelloConnectorMock.GetMemberJiraCardsFunc: method is nil but TrelloConnector.GetMemberJiraCards was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+		S               string
+	}{
+		ContextMoqParam: contextMoqParam,
+		S:               s,
+	}
+	mock.lockGetMemberJiraCards.Lock()
+	mock.calls.GetMemberJiraCards = append(mock.calls.GetMemberJiraCards, callInfo)
+	mock.lockGetMemberJiraCards.Unlock()
+	return mock.GetMemberJiraCardsFunc(contextMoqParam, s)
+}
+
+// GetMemberJiraCardsCalls gets all the calls that were made to GetMemberJiraCards.
+// Check the length with:
+//
+//	len(mockedTrelloConnector.GetMemberJiraCardsCalls())
+func (mock *TrelloConnectorMock) GetMemberJiraCardsCalls() []struct {
+	ContextMoqParam context.Context
+	S               string
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+		S               string
+	}
+	mock.lockGetMemberJiraCards.RLock()
+	calls = mock.calls.GetMemberJiraCards
+	mock.lockGetMemberJiraCards.RUnlock()
+	return calls
+}
+
+// GetMembers calls GetMembersFunc.
+func (mock *TrelloConnectorMock) GetMembers(contextMoqParam context.Context) (map[string]*trello.Member, error) {
+	if mock.GetMembersFunc == nil {
+		panic("TrelloConnectorMock.GetMembersFunc: method is nil but TrelloConnector.GetMembers was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+	}{
+		ContextMoqParam: contextMoqParam,
+	}
+	mock.lockGetMembers.Lock()
+	mock.calls.GetMembers = append(mock.calls.GetMembers, callInfo)
+	mock.lockGetMembers.Unlock()
+	return mock.GetMembersFunc(contextMoqParam)
+}
+
+// GetMembersCalls gets all the calls that were made to GetMembers.
+// Check the length with:
+//
+//	len(mockedTrelloConnector.GetMembersCalls())
+func (mock *TrelloConnectorMock) GetMembersCalls() []struct {
+	ContextMoqParam context.Context
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+	}
+	mock.lockGetMembers.RLock()
+	calls = mock.calls.GetMembers
+	mock.lockGetMembers.RUnlock()
+	return calls
+}
+
 // GetUserJiraCards calls GetUserJiraCardsFunc.
-func (mock *TrelloConnectorMock) GetUserJiraCards() ([]*trello.Card, error) {
+func (mock *TrelloConnectorMock) GetUserJiraCards(contextMoqParam context.Context) ([]*trello.Card, error) {
 	if mock.GetUserJiraCardsFunc == nil {
 		panic("TrelloConnectorMock.GetUserJiraCardsFunc: method is nil but TrelloConnector.GetUserJiraCards was just called")
 	}
 	callInfo := struct {
-	}{}
+		ContextMoqParam context.Context
+	}{
+		ContextMoqParam: contextMoqParam,
+	}
 	mock.lockGetUserJiraCards.Lock()
 	mock.calls.GetUserJiraCards = append(mock.calls.GetUserJiraCards, callInfo)
 	mock.lockGetUserJiraCards.Unlock()
-	return mock.GetUserJiraCardsFunc()
+	return mock.GetUserJiraCardsFunc(contextMoqParam)
 }
 
 // GetUserJiraCardsCalls gets all the calls that were made to GetUserJiraCards.
@@ -369,8 +1127,10 @@ func (mock *TrelloConnectorMock) GetUserJiraCards() ([]*trello.Card, error) {
 //
 //	len(mockedTrelloConnector.GetUserJiraCardsCalls())
 func (mock *TrelloConnectorMock) GetUserJiraCardsCalls() []struct {
+	ContextMoqParam context.Context
 } {
 	var calls []struct {
+		ContextMoqParam context.Context
 	}
 	mock.lockGetUserJiraCards.RLock()
 	calls = mock.calls.GetUserJiraCards
@@ -379,21 +1139,23 @@ func (mock *TrelloConnectorMock) GetUserJiraCardsCalls() []struct {
 }
 
 // MoveCardToList calls MoveCardToListFunc.
-func (mock *TrelloConnectorMock) MoveCardToList(s1 string, s2 string) error {
+func (mock *TrelloConnectorMock) MoveCardToList(contextMoqParam context.Context, s1 string, s2 string) error {
 	if mock.MoveCardToListFunc == nil {
 		panic("TrelloConnectorMock.MoveCardToListFunc: method is nil but TrelloConnector.MoveCardToList was just called")
 	}
 	callInfo := struct {
-		S1 string
-		S2 string
+		ContextMoqParam context.Context
+		S1              string
+		S2              string
 	}{
-		S1: s1,
-		S2: s2,
+		ContextMoqParam: contextMoqParam,
+		S1:              s1,
+		S2:              s2,
 	}
 	mock.lockMoveCardToList.Lock()
 	mock.calls.MoveCardToList = append(mock.calls.MoveCardToList, callInfo)
 	mock.lockMoveCardToList.Unlock()
-	return mock.MoveCardToListFunc(s1, s2)
+	return mock.MoveCardToListFunc(contextMoqParam, s1, s2)
 }
 
 // MoveCardToListCalls gets all the calls that were made to MoveCardToList.
@@ -401,12 +1163,14 @@ func (mock *TrelloConnectorMock) MoveCardToList(s1 string, s2 string) error {
 //
 //	len(mockedTrelloConnector.MoveCardToListCalls())
 func (mock *TrelloConnectorMock) MoveCardToListCalls() []struct {
-	S1 string
-	S2 string
+	ContextMoqParam context.Context
+	S1              string
+	S2              string
 } {
 	var calls []struct {
-		S1 string
-		S2 string
+		ContextMoqParam context.Context
+		S1              string
+		S2              string
 	}
 	mock.lockMoveCardToList.RLock()
 	calls = mock.calls.MoveCardToList
@@ -414,17 +1178,56 @@ func (mock *TrelloConnectorMock) MoveCardToListCalls() []struct {
 	return calls
 }
 
+// RegisterWebhook calls RegisterWebhookFunc.
+func (mock *TrelloConnectorMock) RegisterWebhook(contextMoqParam context.Context, s string) error {
+	if mock.RegisterWebhookFunc == nil {
+		panic("TrelloConnectorMock.RegisterWebhookFunc: method is nil but TrelloConnector.RegisterWebhook was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+		S               string
+	}{
+		ContextMoqParam: contextMoqParam,
+		S:               s,
+	}
+	mock.lockRegisterWebhook.Lock()
+	mock.calls.RegisterWebhook = append(mock.calls.RegisterWebhook, callInfo)
+	mock.lockRegisterWebhook.Unlock()
+	return mock.RegisterWebhookFunc(contextMoqParam, s)
+}
+
+// RegisterWebhookCalls gets all the calls that were made to RegisterWebhook.
+// Check the length with:
+//
+//	len(mockedTrelloConnector.RegisterWebhookCalls())
+func (mock *TrelloConnectorMock) RegisterWebhookCalls() []struct {
+	ContextMoqParam context.Context
+	S               string
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+		S               string
+	}
+	mock.lockRegisterWebhook.RLock()
+	calls = mock.calls.RegisterWebhook
+	mock.lockRegisterWebhook.RUnlock()
+	return calls
+}
+
 // SetBoard calls SetBoardFunc.
-func (mock *TrelloConnectorMock) SetBoard() error {
+func (mock *TrelloConnectorMock) SetBoard(contextMoqParam context.Context) error {
 	if mock.SetBoardFunc == nil {
 		panic("TrelloConnectorMock.SetBoardFunc: method is nil but TrelloConnector.SetBoard was just called")
 	}
 	callInfo := struct {
-	}{}
+		ContextMoqParam context.Context
+	}{
+		ContextMoqParam: contextMoqParam,
+	}
 	mock.lockSetBoard.Lock()
 	mock.calls.SetBoard = append(mock.calls.SetBoard, callInfo)
 	mock.lockSetBoard.Unlock()
-	return mock.SetBoardFunc()
+	return mock.SetBoardFunc(contextMoqParam)
 }
 
 // SetBoardCalls gets all the calls that were made to SetBoard.
@@ -432,8 +1235,10 @@ func (mock *TrelloConnectorMock) SetBoard() error {
 //
 //	len(mockedTrelloConnector.SetBoardCalls())
 func (mock *TrelloConnectorMock) SetBoardCalls() []struct {
+	ContextMoqParam context.Context
 } {
 	var calls []struct {
+		ContextMoqParam context.Context
 	}
 	mock.lockSetBoard.RLock()
 	calls = mock.calls.SetBoard
@@ -441,22 +1246,188 @@ func (mock *TrelloConnectorMock) SetBoardCalls() []struct {
 	return calls
 }
 
+// SetCardCustomField calls SetCardCustomFieldFunc.
+func (mock *TrelloConnectorMock) SetCardCustomField(contextMoqParam context.Context, s1 string, customField *trello.CustomField, s2 string) error {
+	if mock.SetCardCustomFieldFunc == nil {
+		panic("TrelloConnectorMock.SetCardCustomFieldFunc: method is nil but TrelloConnector.SetCardCustomField was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+		S1              string
+		CustomField     *trello.CustomField
+		S2              string
+	}{
+		ContextMoqParam: contextMoqParam,
+		S1:              s1,
+		CustomField:     customField,
+		S2:              s2,
+	}
+	mock.lockSetCardCustomField.Lock()
+	mock.calls.SetCardCustomField = append(mock.calls.SetCardCustomField, callInfo)
+	mock.lockSetCardCustomField.Unlock()
+	return mock.SetCardCustomFieldFunc(contextMoqParam, s1, customField, s2)
+}
+
+// SetCardCustomFieldCalls gets all the calls that were made to SetCardCustomField.
+// Check the length with:
+//
+//	len(mockedTrelloConnector.SetCardCustomFieldCalls())
+func (mock *TrelloConnectorMock) SetCardCustomFieldCalls() []struct {
+	ContextMoqParam context.Context
+	S1              string
+	CustomField     *trello.CustomField
+	S2              string
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+		S1              string
+		CustomField     *trello.CustomField
+		S2              string
+	}
+	mock.lockSetCardCustomField.RLock()
+	calls = mock.calls.SetCardCustomField
+	mock.lockSetCardCustomField.RUnlock()
+	return calls
+}
+
+// SetCheckItemState calls SetCheckItemStateFunc.
+func (mock *TrelloConnectorMock) SetCheckItemState(contextMoqParam context.Context, s1 string, s2 string, b bool) error {
+	if mock.SetCheckItemStateFunc == nil {
+		panic("TrelloConnectorMock.SetCheckItemStateFunc: method is nil but TrelloConnector.SetCheckItemState was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+		S1              string
+		S2              string
+		B               bool
+	}{
+		ContextMoqParam: contextMoqParam,
+		S1:              s1,
+		S2:              s2,
+		B:               b,
+	}
+	mock.lockSetCheckItemState.Lock()
+	mock.calls.SetCheckItemState = append(mock.calls.SetCheckItemState, callInfo)
+	mock.lockSetCheckItemState.Unlock()
+	return mock.SetCheckItemStateFunc(contextMoqParam, s1, s2, b)
+}
+
+// SetCheckItemStateCalls gets all the calls that were made to SetCheckItemState.
+// Check the length with:
+//
+//	len(mockedTrelloConnector.SetCheckItemStateCalls())
+func (mock *TrelloConnectorMock) SetCheckItemStateCalls() []struct {
+	ContextMoqParam context.Context
+	S1              string
+	S2              string
+	B               bool
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+		S1              string
+		S2              string
+		B               bool
+	}
+	mock.lockSetCheckItemState.RLock()
+	calls = mock.calls.SetCheckItemState
+	mock.lockSetCheckItemState.RUnlock()
+	return calls
+}
+
+// UnarchiveCard calls UnarchiveCardFunc.
+func (mock *TrelloConnectorMock) UnarchiveCard(contextMoqParam context.Context, s string) error {
+	if mock.UnarchiveCardFunc == nil {
+		panic("TrelloConnectorMock.UnarchiveCardFunc: method is nil but TrelloConnector.UnarchiveCard was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+		S               string
+	}{
+		ContextMoqParam: contextMoqParam,
+		S:               s,
+	}
+	mock.lockUnarchiveCard.Lock()
+	mock.calls.UnarchiveCard = append(mock.calls.UnarchiveCard, callInfo)
+	mock.lockUnarchiveCard.Unlock()
+	return mock.UnarchiveCardFunc(contextMoqParam, s)
+}
+
+// UnarchiveCardCalls gets all the calls that were made to UnarchiveCard.
+// Check the length with:
+//
+//	len(mockedTrelloConnector.UnarchiveCardCalls())
+func (mock *TrelloConnectorMock) UnarchiveCardCalls() []struct {
+	ContextMoqParam context.Context
+	S               string
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+		S               string
+	}
+	mock.lockUnarchiveCard.RLock()
+	calls = mock.calls.UnarchiveCard
+	mock.lockUnarchiveCard.RUnlock()
+	return calls
+}
+
+// UpdateCardDesc calls UpdateCardDescFunc.
+func (mock *TrelloConnectorMock) UpdateCardDesc(contextMoqParam context.Context, s1 string, s2 string) error {
+	if mock.UpdateCardDescFunc == nil {
+		panic("TrelloConnectorMock.UpdateCardDescFunc: method is nil but TrelloConnector.UpdateCardDesc was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+		S1              string
+		S2              string
+	}{
+		ContextMoqParam: contextMoqParam,
+		S1:              s1,
+		S2:              s2,
+	}
+	mock.lockUpdateCardDesc.Lock()
+	mock.calls.UpdateCardDesc = append(mock.calls.UpdateCardDesc, callInfo)
+	mock.lockUpdateCardDesc.Unlock()
+	return mock.UpdateCardDescFunc(contextMoqParam, s1, s2)
+}
+
+// UpdateCardDescCalls gets all the calls that were made to UpdateCardDesc.
+// Check the length with:
+//
+//	len(mockedTrelloConnector.UpdateCardDescCalls())
+func (mock *TrelloConnectorMock) UpdateCardDescCalls() []struct {
+	ContextMoqParam context.Context
+	S1              string
+	S2              string
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+		S1              string
+		S2              string
+	}
+	mock.lockUpdateCardDesc.RLock()
+	calls = mock.calls.UpdateCardDesc
+	mock.lockUpdateCardDesc.RUnlock()
+	return calls
+}
+
 // UpdateCardLabels calls UpdateCardLabelsFunc.
-func (mock *TrelloConnectorMock) UpdateCardLabels(s1 string, s2 string) error {
+func (mock *TrelloConnectorMock) UpdateCardLabels(contextMoqParam context.Context, s1 string, s2 string) error {
 	if mock.UpdateCardLabelsFunc == nil {
 		panic("TrelloConnectorMock.UpdateCardLabelsFunc: method is nil but TrelloConnector.UpdateCardLabels was just called")
 	}
 	callInfo := struct {
-		S1 string
-		S2 string
+		ContextMoqParam context.Context
+		S1              string
+		S2              string
 	}{
-		S1: s1,
-		S2: s2,
+		ContextMoqParam: contextMoqParam,
+		S1:              s1,
+		S2:              s2,
 	}
 	mock.lockUpdateCardLabels.Lock()
 	mock.calls.UpdateCardLabels = append(mock.calls.UpdateCardLabels, callInfo)
 	mock.lockUpdateCardLabels.Unlock()
-	return mock.UpdateCardLabelsFunc(s1, s2)
+	return mock.UpdateCardLabelsFunc(contextMoqParam, s1, s2)
 }
 
 // UpdateCardLabelsCalls gets all the calls that were made to UpdateCardLabels.
@@ -464,15 +1435,97 @@ func (mock *TrelloConnectorMock) UpdateCardLabels(s1 string, s2 string) error {
 //
 //	len(mockedTrelloConnector.UpdateCardLabelsCalls())
 func (mock *TrelloConnectorMock) UpdateCardLabelsCalls() []struct {
-	S1 string
-	S2 string
+	ContextMoqParam context.Context
+	S1              string
+	S2              string
 } {
 	var calls []struct {
-		S1 string
-		S2 string
+		ContextMoqParam context.Context
+		S1              string
+		S2              string
 	}
 	mock.lockUpdateCardLabels.RLock()
 	calls = mock.calls.UpdateCardLabels
 	mock.lockUpdateCardLabels.RUnlock()
 	return calls
 }
+
+// UpdateCardMembers calls UpdateCardMembersFunc.
+func (mock *TrelloConnectorMock) UpdateCardMembers(contextMoqParam context.Context, s1 string, s2 string) error {
+	if mock.UpdateCardMembersFunc == nil {
+		panic("TrelloConnectorMock.UpdateCardMembersFunc: method is nil but TrelloConnector.UpdateCardMembers was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+		S1              string
+		S2              string
+	}{
+		ContextMoqParam: contextMoqParam,
+		S1:              s1,
+		S2:              s2,
+	}
+	mock.lockUpdateCardMembers.Lock()
+	mock.calls.UpdateCardMembers = append(mock.calls.UpdateCardMembers, callInfo)
+	mock.lockUpdateCardMembers.Unlock()
+	return mock.UpdateCardMembersFunc(contextMoqParam, s1, s2)
+}
+
+// UpdateCardMembersCalls gets all the calls that were made to UpdateCardMembers.
+// Check the length with:
+//
+//	len(mockedTrelloConnector.UpdateCardMembersCalls())
+func (mock *TrelloConnectorMock) UpdateCardMembersCalls() []struct {
+	ContextMoqParam context.Context
+	S1              string
+	S2              string
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+		S1              string
+		S2              string
+	}
+	mock.lockUpdateCardMembers.RLock()
+	calls = mock.calls.UpdateCardMembers
+	mock.lockUpdateCardMembers.RUnlock()
+	return calls
+}
+
+// UpdateCardName calls UpdateCardNameFunc.
+func (mock *TrelloConnectorMock) UpdateCardName(contextMoqParam context.Context, s1 string, s2 string) error {
+	if mock.UpdateCardNameFunc == nil {
+		panic("TrelloConnectorMock.UpdateCardNameFunc: method is nil but TrelloConnector.UpdateCardName was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+		S1              string
+		S2              string
+	}{
+		ContextMoqParam: contextMoqParam,
+		S1:              s1,
+		S2:              s2,
+	}
+	mock.lockUpdateCardName.Lock()
+	mock.calls.UpdateCardName = append(mock.calls.UpdateCardName, callInfo)
+	mock.lockUpdateCardName.Unlock()
+	return mock.UpdateCardNameFunc(contextMoqParam, s1, s2)
+}
+
+// UpdateCardNameCalls gets all the calls that were made to UpdateCardName.
+// Check the length with:
+//
+//	len(mockedTrelloConnector.UpdateCardNameCalls())
+func (mock *TrelloConnectorMock) UpdateCardNameCalls() []struct {
+	ContextMoqParam context.Context
+	S1              string
+	S2              string
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+		S1              string
+		S2              string
+	}
+	mock.lockUpdateCardName.RLock()
+	calls = mock.calls.UpdateCardName
+	mock.lockUpdateCardName.RUnlock()
+	return calls
+}
@@ -0,0 +1,305 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tuiCard is a card shown by TUI, task is nil if card's task isn't found by sync query, e.g. it's done.
+type tuiCard struct {
+	card *trello.Card
+	task *jira.Task
+}
+
+// link returns Jira link of the card.
+func (c *tuiCard) link() string {
+	switch {
+	case c.task != nil:
+		return c.task.Link
+	case c.card.Marker != nil:
+		return c.card.Marker.Link
+	}
+
+	return ""
+}
+
+type tuiList struct {
+	name  string
+	id    string
+	cards []*tuiCard
+}
+
+// tuiBoard is a state of TUI dashboard, col and row point to selected card.
+type tuiBoard struct {
+	sync    *SyncService
+	lists   []*tuiList
+	col     int
+	row     int
+	message string
+	// prompt asks user for input, it returns false if input is canceled.
+	prompt func(label string) (string, bool)
+	// open opens link in browser.
+	open func(link string) error
+	// runSync runs sync, the board is reloaded after it.
+	runSync func(ctx context.Context) error
+}
+
+// load gets user cards with Jira tasks of sync query and places them to configured lists,
+// only cards owned by the user are loaded on shared board.
+func (b *tuiBoard) load(ctx context.Context) error {
+	s := b.sync
+
+	query, err := s.jql.syncJQL(time.Now())
+	if err != nil {
+		return fmt.Errorf("can't get jira query: %w", err)
+	}
+
+	tasks, err := s.jCli.GetTasks(ctx, query)
+	if err != nil {
+		return fmt.Errorf("can't get jira tasks: %w", err)
+	}
+
+	if err := s.loadCards(ctx); err != nil {
+		return err
+	}
+
+	cards := make([]*trello.Card, 0, len(s.tCards))
+	for _, card := range s.tCards {
+		cards = append(cards, card)
+	}
+
+	sort.Slice(cards, func(i, j int) bool {
+		return cards[i].Key < cards[j].Key
+	})
+
+	lists := s.tCli.GetConfig().Lists
+	byID := map[string]*tuiList{}
+	b.lists = nil
+
+	for _, id := range []string{lists.Todo, lists.Doing, lists.Review, lists.Done, lists.Bucket} {
+		if id == "" {
+			continue
+		}
+
+		list := &tuiList{name: trello.GetListNameByID(id, lists), id: id}
+		b.lists = append(b.lists, list)
+		byID[id] = list
+	}
+
+	for _, card := range cards {
+		if list, ok := byID[card.ListID]; ok {
+			list.cards = append(list.cards, &tuiCard{card: card, task: tasks[card.Key]})
+		}
+	}
+
+	b.selectCard(b.col, b.row)
+
+	return nil
+}
+
+// selectCard selects the card, position is clamped to existing lists and cards.
+func (b *tuiBoard) selectCard(col, row int) {
+	if len(b.lists) == 0 {
+		b.col, b.row = 0, 0
+
+		return
+	}
+
+	b.col = max(0, min(col, len(b.lists)-1))
+	b.row = max(0, min(row, len(b.lists[b.col].cards)-1))
+}
+
+// selected returns selected card, it's nil if selected list is empty.
+func (b *tuiBoard) selected() *tuiCard {
+	if b.col >= len(b.lists) || b.row >= len(b.lists[b.col].cards) {
+		return nil
+	}
+
+	return b.lists[b.col].cards[b.row]
+}
+
+// handleKey applies action of the key, it returns false when user quits.
+func (b *tuiBoard) handleKey(ctx context.Context, key string) bool {
+	var err error
+
+	b.message = ""
+
+	switch key {
+	case "q", keyCtrlC:
+		return false
+	case keyUp, "k":
+		b.selectCard(b.col, b.row-1)
+	case keyDown, "j":
+		b.selectCard(b.col, b.row+1)
+	case keyLeft, "h":
+		b.selectCard(b.col-1, b.row)
+	case keyRight, "l":
+		b.selectCard(b.col+1, b.row)
+	case "<":
+		err = b.moveSelected(ctx, -1)
+	case ">":
+		err = b.moveSelected(ctx, 1)
+	case "t":
+		err = b.transitionSelected(ctx)
+	case "w":
+		err = b.logWork(ctx)
+	case "o", keyEnter:
+		err = b.openSelected()
+	case "s":
+		if err = b.runSync(ctx); err == nil {
+			err = b.load(ctx)
+			b.message = "Sync completed"
+		}
+	case "r":
+		if err = b.load(ctx); err == nil {
+			b.message = "Board reloaded"
+		}
+	}
+
+	if err != nil {
+		b.message = "Error: " + err.Error()
+	}
+
+	return true
+}
+
+var errNoCard = errors.New("no card selected")
+
+// moveSelected moves selected card to the next list in direction of delta.
+func (b *tuiBoard) moveSelected(ctx context.Context, delta int) error {
+	c := b.selected()
+	if c == nil {
+		return errNoCard
+	}
+
+	to := b.col + delta
+	if to < 0 || to >= len(b.lists) {
+		return nil
+	}
+
+	target := b.lists[to]
+
+	if err := b.sync.tCli.MoveCardToList(ctx, c.card.ID, target.id); err != nil {
+		return fmt.Errorf("can't move card: %w", err)
+	}
+
+	from := b.lists[b.col]
+	from.cards = append(from.cards[:b.row], from.cards[b.row+1:]...)
+	c.card.ListID = target.id
+	target.cards = append(target.cards, c)
+
+	sort.Slice(target.cards, func(i, j int) bool {
+		return target.cards[i].card.Key < target.cards[j].card.Key
+	})
+
+	for i, card := range target.cards {
+		if card == c {
+			b.selectCard(to, i)
+		}
+	}
+
+	b.message = fmt.Sprintf("%s moved to %s", c.card.Key, target.name)
+
+	return nil
+}
+
+// transitionSelected asks for one of available transitions and moves Jira issue of selected card along it.
+func (b *tuiBoard) transitionSelected(ctx context.Context) error {
+	c := b.selected()
+	if c == nil {
+		return errNoCard
+	}
+
+	transitions, err := b.sync.jCli.GetTransitions(ctx, c.card.Key)
+	if err != nil {
+		return fmt.Errorf("can't get transitions: %w", err)
+	}
+
+	if len(transitions) == 0 {
+		return fmt.Errorf("no transitions available for %s", c.card.Key)
+	}
+
+	options := make([]string, 0, len(transitions))
+	for i, t := range transitions {
+		options = append(options, fmt.Sprintf("%d) %s", i+1, t.Name))
+	}
+
+	answer, ok := b.prompt(fmt.Sprintf("Transition %s: %s: ", c.card.Key, strings.Join(options, ", ")))
+	if !ok {
+		return nil
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(answer))
+	if err != nil || n < 1 || n > len(transitions) {
+		return fmt.Errorf("unknown transition `%s`", answer)
+	}
+
+	t := transitions[n-1]
+
+	if err := b.sync.jCli.DoTransition(ctx, c.card.Key, t.ID); err != nil {
+		return fmt.Errorf("can't transition issue: %w", err)
+	}
+
+	if c.task != nil {
+		c.task.Status = t.To
+	}
+
+	b.message = fmt.Sprintf("%s transitioned to %s, sync to move the card", c.card.Key, t.To)
+
+	return nil
+}
+
+// logWork asks for time spent and logs it to Jira issue of selected card.
+func (b *tuiBoard) logWork(ctx context.Context) error {
+	c := b.selected()
+	if c == nil {
+		return errNoCard
+	}
+
+	answer, ok := b.prompt(fmt.Sprintf("Time spent on %s, e.g. 1h30m: ", c.card.Key))
+	if !ok {
+		return nil
+	}
+
+	spent, err := time.ParseDuration(strings.TrimSpace(answer))
+	if err != nil {
+		return fmt.Errorf("can't parse time spent: %w", err)
+	}
+
+	if err := b.sync.jCli.AddWorklog(ctx, c.card.Key, spent); err != nil {
+		return fmt.Errorf("can't log work: %w", err)
+	}
+
+	if c.task != nil {
+		c.task.TimeSpent += spent
+	}
+
+	b.message = fmt.Sprintf("%s logged on %s", spent, c.card.Key)
+
+	return nil
+}
+
+func (b *tuiBoard) openSelected() error {
+	c := b.selected()
+	if c == nil {
+		return errNoCard
+	}
+
+	link := c.link()
+	if link == "" {
+		return fmt.Errorf("no Jira link for %s", c.card.Key)
+	}
+
+	if err := b.open(link); err != nil {
+		return fmt.Errorf("can't open link: %w", err)
+	}
+
+	return nil
+}
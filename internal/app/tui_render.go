@@ -0,0 +1,116 @@
+package app
+
+import (
+	"fmt"
+	"github.com/Brialius/jira2trello/internal/theme"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	tuiHelp = "←↓↑→ select  < > move  t transition  w log work  o open  s sync  r reload  q quit"
+	// tuiCardLines is a number of lines of each card.
+	tuiCardLines = 2
+	tuiColumnGap = "  "
+)
+
+// render returns screen lines of the board fitted to terminal size.
+func (b *tuiBoard) render(th *theme.Theme, width, height int) []string {
+	res := make([]string, 0, height)
+
+	if len(b.lists) == 0 {
+		res = append(res, "No lists configured, run `configure` to fix")
+	} else {
+		colWidth := max(1, (width-len(tuiColumnGap)*(len(b.lists)-1))/len(b.lists))
+		// Header, message and help lines aren't used by cards.
+		visible := max(1, (height-3)/tuiCardLines)
+		columns := make([][]string, 0, len(b.lists))
+
+		for i, list := range b.lists {
+			columns = append(columns, b.renderList(th, list, i, colWidth, visible))
+		}
+
+		for line := 0; line < len(columns[0]); line++ {
+			cells := make([]string, 0, len(columns))
+
+			for _, column := range columns {
+				cells = append(cells, column[line])
+			}
+
+			res = append(res, strings.TrimRight(strings.Join(cells, tuiColumnGap), " "))
+		}
+	}
+
+	for len(res) < height-2 {
+		res = append(res, "")
+	}
+
+	return append(res, truncate(b.message, width), th.Paint(theme.IBlack, truncate(tuiHelp, width)))
+}
+
+// renderList returns lines of list column, each line is padded to the width.
+// Selected card is marked and its column is scrolled to keep it visible.
+func (b *tuiBoard) renderList(th *theme.Theme, list *tuiList, col, width, visible int) []string {
+	header := truncate(fmt.Sprintf("%s (%d)", list.name, len(list.cards)), width)
+	lines := []string{pad(th.Paint(theme.BWhite, header), utf8.RuneCountInString(header), width)}
+
+	offset := 0
+	if col == b.col && b.row >= visible {
+		offset = b.row - visible + 1
+	}
+
+	for i := offset; i < offset+visible; i++ {
+		if i >= len(list.cards) {
+			lines = append(lines, strings.Repeat(" ", width), strings.Repeat(" ", width))
+
+			continue
+		}
+
+		c := list.cards[i]
+		selected := col == b.col && i == b.row
+
+		status := ""
+		if c.task != nil {
+			status = c.task.Status
+		}
+
+		summary := strings.TrimPrefix(c.card.Name, c.card.Key+" | ")
+
+		marker := "  "
+		if selected {
+			marker = "> "
+		}
+
+		key := truncate(marker+c.card.Key, width)
+		status = truncate(status, max(0, width-utf8.RuneCountInString(key)-1))
+		first := key
+		firstWidth := utf8.RuneCountInString(key)
+
+		if status != "" {
+			firstWidth += 1 + utf8.RuneCountInString(status)
+
+			if selected {
+				first += " " + status
+			} else {
+				first += " " + th.Status(status)
+			}
+		}
+
+		second := truncate("  "+summary, width)
+
+		if selected {
+			lines = append(lines,
+				th.Paint(theme.Reverse, pad(first, firstWidth, width)),
+				th.Paint(theme.Reverse, pad(second, utf8.RuneCountInString(second), width)))
+		} else {
+			lines = append(lines, pad(first, firstWidth, width), pad(second, utf8.RuneCountInString(second), width))
+		}
+	}
+
+	return lines
+}
+
+// pad appends spaces to text of textWidth visible chars, so it fills the width.
+func pad(text string, textWidth, width int) string {
+	return text + strings.Repeat(" ", max(0, width-textWidth))
+}
@@ -0,0 +1,327 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Brialius/jira2trello/internal/logging"
+	"golang.org/x/term"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"unicode/utf8"
+)
+
+// Names of special keys returned by readKey.
+const (
+	keyUp        = "up"
+	keyDown      = "down"
+	keyLeft      = "left"
+	keyRight     = "right"
+	keyEnter     = "enter"
+	keyEsc       = "esc"
+	keyBackspace = "backspace"
+	keyCtrlC     = "ctrl+c"
+)
+
+const (
+	escEnterAltScreen = "\033[?1049h\033[?25l"
+	escExitAltScreen  = "\033[?25h\033[?1049l"
+	escClearScreen    = "\033[H\033[2J"
+)
+
+// TUI runs full screen dashboard of user cards in configured lists.
+func (s *SyncService) TUI(ctx context.Context) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		log.Fatalf("Can't start TUI: stdin and stdout should be terminal")
+	}
+
+	if err := s.connect(ctx); err != nil {
+		log.Fatalf("Can't connect: %s", err)
+	}
+
+	b := &tuiBoard{sync: s, open: openLink}
+
+	slog.Info("Loading board")
+
+	if err := b.load(ctx); err != nil {
+		log.Fatalf("Can't load board: %s", err)
+	}
+
+	t, err := newTerminal(os.Stdin, os.Stdout)
+	if err != nil {
+		log.Fatalf("Can't start TUI: %s", err)
+	}
+
+	draw := func() {
+		width, height := t.size()
+		t.draw(b.render(s.theme, width, height))
+	}
+
+	b.prompt = func(label string) (string, bool) {
+		return t.prompt(ctx, func(input string) {
+			b.message = label + input
+			draw()
+		})
+	}
+
+	b.runSync = func(ctx context.Context) error {
+		return t.suspend(ctx, func() error {
+			s.result = newSyncResult()
+
+			return s.sync(ctx, true)
+		})
+	}
+
+	err = t.run(ctx, draw, func(key string) bool {
+		return b.handleKey(ctx, key)
+	})
+
+	if closeErr := t.close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		log.Fatalf("TUI failed: %s", err)
+	}
+}
+
+// keyPress is a key read from terminal or read error.
+type keyPress struct {
+	key string
+	err error
+}
+
+// terminal is a full screen terminal in raw mode.
+type terminal struct {
+	in    *os.File
+	out   *os.File
+	keys  chan keyPress
+	state *term.State
+	// release writes logs held while dashboard is shown.
+	release func()
+}
+
+func newTerminal(in, out *os.File) (*terminal, error) {
+	t := &terminal{in: in, out: out, keys: make(chan keyPress)}
+
+	// Keys are read in background, so waiting for a key is interrupted when context is done.
+	go func() {
+		r := bufio.NewReader(in)
+
+		for {
+			key, err := readKey(r)
+			t.keys <- keyPress{key: key, err: err}
+
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return t, t.enter()
+}
+
+// enter switches terminal to raw mode and alternate screen, logs are held until terminal is restored.
+func (t *terminal) enter() error {
+	state, err := term.MakeRaw(int(t.in.Fd()))
+	if err != nil {
+		return fmt.Errorf("can't switch terminal to raw mode: %w", err)
+	}
+
+	t.state = state
+	t.release = logging.HoldConsole()
+	_, _ = io.WriteString(t.out, escEnterAltScreen)
+
+	return nil
+}
+
+// close restores terminal state and main screen, then writes logs held while dashboard was shown.
+func (t *terminal) close() error {
+	_, _ = io.WriteString(t.out, escExitAltScreen)
+
+	err := term.Restore(int(t.in.Fd()), t.state)
+
+	if t.release != nil {
+		t.release()
+		t.release = nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("can't restore terminal: %w", err)
+	}
+
+	return nil
+}
+
+// suspend restores terminal for fn, so its output is printed to main screen, and waits for Enter after it.
+func (t *terminal) suspend(ctx context.Context, fn func() error) error {
+	if err := t.close(); err != nil {
+		return err
+	}
+
+	err := fn()
+
+	_, _ = fmt.Fprint(t.out, "\nPress Enter to return to dashboard")
+
+	for {
+		key, keyErr := t.readKey(ctx)
+		if keyErr != nil || key == keyEnter {
+			break
+		}
+	}
+
+	if enterErr := t.enter(); enterErr != nil {
+		return errors.Join(err, enterErr)
+	}
+
+	return err
+}
+
+// readKey waits for a key until context is done.
+func (t *terminal) readKey(ctx context.Context) (string, error) {
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case k := <-t.keys:
+		return k.key, k.err
+	}
+}
+
+func (t *terminal) size() (int, int) {
+	const defaultWidth, defaultHeight = 80, 24
+
+	width, height, err := term.GetSize(int(t.out.Fd()))
+	if err != nil {
+		return defaultWidth, defaultHeight
+	}
+
+	return width, height
+}
+
+func (t *terminal) draw(lines []string) {
+	_, _ = io.WriteString(t.out, escClearScreen+strings.Join(lines, "\r\n"))
+}
+
+// run draws the screen and handles keys until handle returns false or context is done.
+func (t *terminal) run(ctx context.Context, draw func(), handle func(key string) bool) error {
+	for {
+		draw()
+
+		key, err := t.readKey(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if !handle(key) {
+			return nil
+		}
+	}
+}
+
+// prompt reads line of input, show is called to display input on each change.
+// It returns false if input is canceled with Esc or Ctrl+C or context is done.
+func (t *terminal) prompt(ctx context.Context, show func(input string)) (string, bool) {
+	input := make([]rune, 0)
+
+	for {
+		show(string(input))
+
+		key, err := t.readKey(ctx)
+		if err != nil {
+			return "", false
+		}
+
+		switch key {
+		case keyEnter:
+			return string(input), true
+		case keyEsc, keyCtrlC:
+			return "", false
+		case keyBackspace:
+			if len(input) > 0 {
+				input = input[:len(input)-1]
+			}
+		default:
+			if utf8.RuneCountInString(key) == 1 {
+				input = append(input, []rune(key)...)
+			}
+		}
+	}
+}
+
+// readKey reads a key pressed in raw mode, special keys are returned by name, other keys as typed.
+func readKey(r *bufio.Reader) (string, error) {
+	ch, _, err := r.ReadRune()
+	if err != nil {
+		// todo: error returned from external package is unwrapped
+		return "", err
+	}
+
+	switch ch {
+	case 3:
+		return keyCtrlC, nil
+	case '\r', '\n':
+		return keyEnter, nil
+	case 127, '\b':
+		return keyBackspace, nil
+	case 27:
+		// Escape sequences of arrows are read at once, so lone Esc has nothing buffered after it.
+		if r.Buffered() < 2 {
+			return keyEsc, nil
+		}
+
+		seq := make([]byte, 2)
+		if _, err := io.ReadFull(r, seq); err != nil {
+			// todo: error returned from external package is unwrapped
+			return "", err
+		}
+
+		switch string(seq) {
+		case "[A", "OA":
+			return keyUp, nil
+		case "[B", "OB":
+			return keyDown, nil
+		case "[C", "OC":
+			return keyRight, nil
+		case "[D", "OD":
+			return keyLeft, nil
+		}
+
+		return keyEsc, nil
+	}
+
+	return string(ch), nil
+}
+
+// openLink opens link in default browser.
+func openLink(link string) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", link)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", link)
+	default:
+		cmd = exec.Command("xdg-open", link)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("can't start browser: %w", err)
+	}
+
+	go func() {
+		_ = cmd.Wait()
+	}()
+
+	return nil
+}
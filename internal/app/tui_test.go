@@ -0,0 +1,309 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_readKey(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("q\x1b[A\x1b[B\x1b[C\x1b[D\r\x7f\x03я"))
+
+	keys := make([]string, 0)
+
+	for {
+		key, err := readKey(r)
+		if err != nil {
+			break
+		}
+
+		keys = append(keys, key)
+	}
+
+	require.Equal(t, []string{"q", keyUp, keyDown, keyRight, keyLeft, keyEnter, keyBackspace, keyCtrlC, "я"}, keys)
+
+	key, err := readKey(bufio.NewReader(strings.NewReader("\x1b")))
+	require.NoError(t, err)
+	require.Equal(t, keyEsc, key)
+}
+
+func getTestBoard(t *testing.T) (*tuiBoard, *JiraConnectorMock, *TrelloConnectorMock) {
+	t.Helper()
+
+	var jTasks = map[string]*jira.Task{}
+	mustLoadJSONFile(t, "testdata/test_jira_tasks.json", &jTasks)
+
+	tCards := make([]*trello.Card, 0)
+	mustLoadJSONFile(t, "testdata/test_trello_cards.json", &tCards)
+
+	jCli := GetJiraMockedCli(jTasks)
+	tCli := GetTrelloMockedCli(tCards)
+	b := &tuiBoard{sync: &SyncService{jCli: jCli, tCli: tCli}}
+
+	require.NoError(t, b.load(context.Background()))
+
+	return b, jCli, tCli
+}
+
+func TestTuiBoard_load(t *testing.T) {
+	b, _, _ := getTestBoard(t)
+
+	names := make([]string, 0)
+	counts := make([]int, 0)
+
+	for _, list := range b.lists {
+		names = append(names, list.name)
+		counts = append(counts, len(list.cards))
+	}
+
+	require.Equal(t, []string{"Todo", "Doing", "Review", "Done", "Bucket"}, names)
+	require.Equal(t, []int{3, 16, 1, 2, 0}, counts)
+	require.Equal(t, "JIRA1-1110", b.selected().card.Key)
+	require.NotNil(t, b.selected().task)
+}
+
+func TestTuiBoard_load_sharedBoard(t *testing.T) {
+	const (
+		me  = "111111111111111111111111"
+		bob = "333333333333333333333333"
+	)
+
+	tCli := GetTrelloMockedCli(nil)
+	cfg := tCli.GetConfig()
+	cfg.Shared = true
+	tCli.GetConfigFunc = func() *trello.Config {
+		return cfg
+	}
+	tCli.GetJiraCardsFunc = func(ctx context.Context) ([]*trello.Card, error) {
+		return []*trello.Card{
+			{ID: "c1", Key: "K-1", ListID: cfg.Lists.Todo, IDMembers: me},
+			{ID: "c2", Key: "K-2", ListID: cfg.Lists.Todo, IDMembers: me,
+				Marker: &trello.Marker{Key: "K-2", Owner: bob}},
+			{ID: "c3", Key: "K-3", ListID: cfg.Lists.Doing, IDMembers: bob,
+				Marker: &trello.Marker{Key: "K-3", Owner: me}},
+		}, nil
+	}
+
+	jCli := GetJiraMockedCli(map[string]*jira.Task{})
+	jCli.GetSelfFunc = func(ctx context.Context) (*jira.User, error) {
+		return &jira.User{Name: "me"}, nil
+	}
+
+	b := &tuiBoard{sync: &SyncService{jCli: jCli, tCli: tCli}}
+	require.NoError(t, b.load(context.Background()))
+
+	keys := make([]string, 0)
+
+	for _, list := range b.lists {
+		for _, c := range list.cards {
+			keys = append(keys, c.card.Key)
+		}
+	}
+
+	require.Equal(t, []string{"K-1", "K-3"}, keys)
+	require.Empty(t, tCli.GetUserJiraCardsCalls())
+}
+
+func TestTuiBoard_handleKey(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		keys    []string
+		wantCol int
+		wantRow int
+	}{
+		{name: "down", keys: []string{keyDown, "j"}, wantCol: 0, wantRow: 2},
+		{name: "down at the end", keys: []string{keyDown, keyDown, keyDown, keyDown}, wantCol: 0, wantRow: 2},
+		{name: "up at the top", keys: []string{keyUp, "k"}, wantCol: 0, wantRow: 0},
+		{name: "right keeps row in range", keys: []string{keyDown, keyDown, keyRight, "l"}, wantCol: 2, wantRow: 0},
+		{name: "right to empty list", keys: []string{keyRight, keyRight, keyRight, keyRight, keyRight}, wantCol: 4},
+		{name: "left at the start", keys: []string{keyLeft, "h"}, wantCol: 0, wantRow: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, _, _ := getTestBoard(t)
+
+			for _, key := range tt.keys {
+				require.True(t, b.handleKey(ctx, key))
+			}
+
+			require.Equal(t, tt.wantCol, b.col)
+			require.Equal(t, tt.wantRow, b.row)
+		})
+	}
+
+	b, _, _ := getTestBoard(t)
+	require.False(t, b.handleKey(ctx, "q"))
+	require.False(t, b.handleKey(ctx, keyCtrlC))
+
+	b.selectCard(4, 0)
+	require.True(t, b.handleKey(ctx, ">"))
+	require.Equal(t, "Error: no card selected", b.message)
+}
+
+func TestTuiBoard_moveSelected(t *testing.T) {
+	ctx := context.Background()
+	b, _, tCli := getTestBoard(t)
+
+	// Card of the first list can't be moved further left.
+	require.True(t, b.handleKey(ctx, "<"))
+	require.Empty(t, b.message)
+	require.Empty(t, tCli.MoveCardToListCalls())
+
+	require.True(t, b.handleKey(ctx, ">"))
+	require.Equal(t, "JIRA1-1110 moved to Doing", b.message)
+	require.Equal(t, 1, b.col)
+	require.Equal(t, "JIRA1-1110", b.selected().card.Key)
+	require.Len(t, b.lists[0].cards, 2)
+	require.Len(t, b.lists[1].cards, 17)
+
+	calls := tCli.MoveCardToListCalls()
+	require.Len(t, calls, 1)
+	require.Equal(t, "098098098098098098098001", calls[0].S1)
+	require.Equal(t, "12345678909876543219d1cb", calls[0].S2)
+
+	tCli.MoveCardToListFunc = func(ctx context.Context, in1 string, in2 string) error {
+		return errors.New("trello is down")
+	}
+
+	require.True(t, b.handleKey(ctx, "<"))
+	require.Equal(t, "Error: can't move card: trello is down", b.message)
+	require.Len(t, b.lists[0].cards, 2)
+}
+
+func TestTuiBoard_transitionSelected(t *testing.T) {
+	ctx := context.Background()
+	b, jCli, _ := getTestBoard(t)
+
+	jCli.GetTransitionsFunc = func(ctx context.Context, key string) ([]*jira.Transition, error) {
+		return []*jira.Transition{
+			{ID: "11", Name: "Start", To: "In Progress"},
+			{ID: "21", Name: "Block", To: "Blocked"},
+		}, nil
+	}
+	jCli.DoTransitionFunc = func(ctx context.Context, key string, transitionID string) error {
+		return nil
+	}
+
+	labels := make([]string, 0)
+	answer := "2"
+	b.prompt = func(label string) (string, bool) {
+		labels = append(labels, label)
+
+		return answer, answer != ""
+	}
+
+	require.True(t, b.handleKey(ctx, "t"))
+	require.Equal(t, []string{"Transition JIRA1-1110: 1) Start, 2) Block: "}, labels)
+	require.Equal(t, "JIRA1-1110 transitioned to Blocked, sync to move the card", b.message)
+	require.Equal(t, "Blocked", b.selected().task.Status)
+
+	calls := jCli.DoTransitionCalls()
+	require.Len(t, calls, 1)
+	require.Equal(t, "JIRA1-1110", calls[0].Key)
+	require.Equal(t, "21", calls[0].TransitionID)
+
+	answer = "3"
+	require.True(t, b.handleKey(ctx, "t"))
+	require.Equal(t, "Error: unknown transition `3`", b.message)
+
+	answer = ""
+	require.True(t, b.handleKey(ctx, "t"))
+	require.Empty(t, b.message)
+	require.Len(t, jCli.DoTransitionCalls(), 1)
+}
+
+func TestTuiBoard_logWork(t *testing.T) {
+	ctx := context.Background()
+	b, jCli, _ := getTestBoard(t)
+
+	jCli.AddWorklogFunc = func(ctx context.Context, key string, spent time.Duration) error {
+		return nil
+	}
+
+	answer := "1h30m"
+	b.prompt = func(label string) (string, bool) {
+		return answer, true
+	}
+
+	spent := b.selected().task.TimeSpent
+
+	require.True(t, b.handleKey(ctx, "w"))
+	require.Equal(t, "1h30m0s logged on JIRA1-1110", b.message)
+	require.Equal(t, spent+90*time.Minute, b.selected().task.TimeSpent)
+
+	calls := jCli.AddWorklogCalls()
+	require.Len(t, calls, 1)
+	require.Equal(t, "JIRA1-1110", calls[0].Key)
+	require.Equal(t, 90*time.Minute, calls[0].Spent)
+
+	answer = "a lot"
+	require.True(t, b.handleKey(ctx, "w"))
+	require.Contains(t, b.message, "Error: can't parse time spent")
+	require.Len(t, jCli.AddWorklogCalls(), 1)
+}
+
+func TestTuiBoard_openSelected(t *testing.T) {
+	ctx := context.Background()
+	b, _, _ := getTestBoard(t)
+
+	opened := make([]string, 0)
+	b.open = func(link string) error {
+		opened = append(opened, link)
+
+		return nil
+	}
+
+	require.True(t, b.handleKey(ctx, "o"))
+	require.True(t, b.handleKey(ctx, keyEnter))
+	require.Equal(t, []string{b.selected().task.Link, b.selected().task.Link}, opened)
+	require.NotEmpty(t, opened[0])
+}
+
+func TestTuiBoard_sync(t *testing.T) {
+	ctx := context.Background()
+	b, _, tCli := getTestBoard(t)
+
+	b.runSync = func(ctx context.Context) error {
+		return nil
+	}
+
+	require.True(t, b.handleKey(ctx, "s"))
+	require.Equal(t, "Sync completed", b.message)
+	require.Len(t, tCli.GetUserJiraCardsCalls(), 2)
+
+	b.runSync = func(ctx context.Context) error {
+		return errors.New("jira is down")
+	}
+
+	require.True(t, b.handleKey(ctx, "s"))
+	require.Equal(t, "Error: jira is down", b.message)
+	require.Len(t, tCli.GetUserJiraCardsCalls(), 2)
+}
+
+func TestTuiBoard_render(t *testing.T) {
+	b, _, _ := getTestBoard(t)
+	b.message = "Board reloaded"
+
+	lines := b.render(nil, 60, 8)
+
+	require.Len(t, lines, 8)
+	require.Equal(t, []string{
+		"Todo (3)    Doing (16)  Review (1)  Done (2)    Bucket (0)",
+		"> JIRA1-1…    JIRA1-1…    JIRA1-1…    JIRA1-1…",
+		"  Test ta…    Test ta…    Test ta…    Test ta…",
+	}, lines[:3])
+	require.Equal(t, "Board reloaded", lines[6])
+	require.Equal(t, truncate(tuiHelp, 60), lines[7])
+
+	lines = (&tuiBoard{message: "message"}).render(nil, 10, 4)
+	require.Equal(t, []string{"No lists configured, run `configure` to fix", "", "message", truncate(tuiHelp, 10)}, lines)
+}
@@ -0,0 +1,204 @@
+package app
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"github.com/Brialius/jira2trello/internal/metrics"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	trelloWebhookPath = "/webhooks/trello"
+	jiraWebhookPath   = "/webhooks/jira"
+	// maxWebhookBody limits size of webhook request body.
+	maxWebhookBody = 1 << 20
+)
+
+// WebhookConfig configures webhooks registered by serve command.
+type WebhookConfig struct {
+	// URL is a public URL of the server, webhooks aren't registered if it's empty.
+	URL string
+	// Secret authenticates Jira webhook requests, it's sent as `secret` query parameter.
+	Secret string
+}
+
+func (c WebhookConfig) trelloURL() string {
+	return strings.TrimRight(c.URL, "/") + trelloWebhookPath
+}
+
+func (c WebhookConfig) jiraURL() string {
+	return strings.TrimRight(c.URL, "/") + jiraWebhookPath + "?secret=" + url.QueryEscape(c.Secret)
+}
+
+// redact replaces secret in text, e.g. in URL or error, so it isn't logged.
+func (c WebhookConfig) redact(s string) string {
+	if c.Secret == "" {
+		return s
+	}
+
+	return strings.NewReplacer(url.QueryEscape(c.Secret), "[REDACTED]", c.Secret, "[REDACTED]").Replace(s)
+}
+
+// trelloWebhookEvent is a part of Trello webhook request, which has changed card.
+type trelloWebhookEvent struct {
+	Action struct {
+		Type string `json:"type"`
+		Data struct {
+			Card *struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"card"`
+		} `json:"data"`
+	} `json:"action"`
+}
+
+// jiraWebhookEvent is a part of Jira webhook request, which has changed issue.
+type jiraWebhookEvent struct {
+	WebhookEvent string `json:"webhookEvent"`
+	Issue        *struct {
+		Key string `json:"key"`
+	} `json:"issue"`
+}
+
+// registerWebhooks registers Trello board and Jira webhooks, cards are still synced by interval
+// if registration fails.
+func (srv *server) registerWebhooks(ctx context.Context) {
+	s := srv.sync
+
+	if s.tCli.GetConfig().Secret == "" {
+		slog.Warn("Trello webhook isn't registered, set trello.secret to verify its requests")
+	} else if err := s.tCli.RegisterWebhook(ctx, srv.webhooks.trelloURL()); err != nil {
+		slog.Warn("Can't register Trello webhook", "error", err)
+	} else {
+		slog.Info("Trello webhook registered", "url", srv.webhooks.trelloURL())
+	}
+
+	if srv.webhooks.Secret == "" {
+		slog.Warn("Jira webhook isn't registered, set webhooks.secret to verify its requests")
+
+		return
+	}
+
+	// Webhook runs without user, so currentUser() of sync query isn't resolved there.
+	self, err := s.jCli.GetSelf(ctx)
+	if err != nil {
+		slog.Warn("Can't get current jira user", "error", err)
+
+		return
+	}
+
+	filter, err := s.jql.webhookJQL(self.Name)
+	if err != nil {
+		slog.Warn("Can't get jira webhook filter", "error", err)
+
+		return
+	}
+
+	if err := s.jCli.RegisterWebhook(ctx, "jira2trello", srv.webhooks.jiraURL(), filter); err != nil {
+		slog.Warn("Can't register Jira webhook, it requires Jira admin permission",
+			"error", srv.webhooks.redact(err.Error()))
+	} else {
+		slog.Info("Jira webhook registered", "url", srv.webhooks.redact(srv.webhooks.jiraURL()))
+	}
+}
+
+// handleTrelloWebhook verifies signature of Trello request and syncs card of the event.
+func (srv *server) handleTrelloWebhook(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	// Trello checks callback URL with HEAD request, when webhook is registered.
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
+	if err != nil {
+		rejectWebhook(w, "can't read request", http.StatusBadRequest)
+
+		return
+	}
+
+	if !trello.VerifyWebhookSignature(srv.sync.tCli.GetConfig().Secret, srv.webhooks.trelloURL(), body,
+		r.Header.Get("X-Trello-Webhook")) {
+		slog.Warn("Trello webhook request with invalid signature")
+		rejectWebhook(w, "invalid signature", http.StatusUnauthorized)
+
+		return
+	}
+
+	var event trelloWebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		rejectWebhook(w, "can't parse request", http.StatusBadRequest)
+
+		return
+	}
+
+	card := event.Action.Data.Card
+	if card == nil {
+		return
+	}
+
+	// Cards without key are skipped.
+	if key := srv.trelloCardKey(ctx, card.ID, card.Name); key != "" {
+		slog.Info("Trello webhook received", "action", event.Action.Type, "key", key)
+		srv.startIssue(ctx, key)
+	}
+}
+
+// trelloCardKey returns Jira key of the card, it's taken from marker in card description, so renamed cards are
+// synced too. Card name is used if the card can't be fetched, e.g. deleted card, which has no marker anymore.
+func (srv *server) trelloCardKey(ctx context.Context, cardID, name string) string {
+	if cardID != "" {
+		card, err := srv.sync.tCli.GetCard(ctx, cardID)
+		if err == nil {
+			return card.Key
+		}
+
+		slog.Warn("Can't get card of Trello webhook", "card", cardID, "error", err)
+	}
+
+	return trello.CardKey(name, "", nil)
+}
+
+// handleJiraWebhook checks secret of Jira request and syncs card of the event issue.
+func (srv *server) handleJiraWebhook(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	secret := r.URL.Query().Get("secret")
+	if srv.webhooks.Secret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(srv.webhooks.Secret)) != 1 {
+		slog.Warn("Jira webhook request with invalid secret")
+		rejectWebhook(w, "invalid secret", http.StatusUnauthorized)
+
+		return
+	}
+
+	var event jiraWebhookEvent
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxWebhookBody)).Decode(&event); err != nil {
+		rejectWebhook(w, "can't parse request", http.StatusBadRequest)
+
+		return
+	}
+
+	if event.Issue == nil || event.Issue.Key == "" {
+		return
+	}
+
+	slog.Info("Jira webhook received", "event", event.WebhookEvent, "key", event.Issue.Key)
+	srv.startIssue(ctx, event.Issue.Key)
+}
+
+// rejectWebhook responds with error status and counts rejected webhook request.
+func rejectWebhook(w http.ResponseWriter, msg string, code int) {
+	metrics.Errors.Inc(errorWebhook)
+	http.Error(w, msg, code)
+}
@@ -0,0 +1,279 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/trello"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+)
+
+// mockIssueQuery makes Jira mock return only the issue of query made by issueJQL.
+func mockIssueQuery(jCli *JiraConnectorMock, jTasks map[string]*jira.Task) {
+	keyRe := regexp.MustCompile(`issuekey = "([^"]+)"`)
+
+	jCli.GetTasksFunc = func(ctx context.Context, jql string) (map[string]*jira.Task, error) {
+		match := keyRe.FindStringSubmatch(jql)
+		if match == nil {
+			return jTasks, nil
+		}
+
+		res := map[string]*jira.Task{}
+		if task, ok := jTasks[match[1]]; ok {
+			res[match[1]] = task
+		}
+
+		return res, nil
+	}
+}
+
+func signTrelloWebhook(secret, callbackURL string, body []byte) string {
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(body)
+	mac.Write([]byte(callbackURL))
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func waitForIssueSync(t *testing.T, srv *server, key string) *syncRun {
+	t.Helper()
+
+	var run *syncRun
+
+	require.Eventually(t, func() bool {
+		srv.stateMu.Lock()
+		defer srv.stateMu.Unlock()
+
+		run = srv.last
+
+		return run != nil && run.Key == key
+	}, time.Second, 10*time.Millisecond)
+
+	return run
+}
+
+func TestServer_handleTrelloWebhook(t *testing.T) {
+	body, err := os.ReadFile("testdata/webhook_trello_update_card.json")
+	require.NoError(t, err)
+
+	const callbackURL = "https://j2t.example.com/webhooks/trello"
+
+	renamed := []byte(`{"action":{"data":{"card":{"id":"098098098098098098098011","name":"Renamed"}}}}`)
+	deleted := []byte(`{"action":{"data":{"card":{"id":"098098098098098098098099","name":"JIRA1-984 | Task"}}}}`)
+	withoutKey := []byte(`{"action":{"data":{"card":{"id":"098098098098098098098098","name":"X"}}}}`)
+
+	tests := []struct {
+		name      string
+		method    string
+		body      []byte
+		signature string
+		wantCode  int
+		wantSync  bool
+	}{
+		{name: "registration check", method: http.MethodHead, wantCode: http.StatusOK},
+		{
+			name: "card updated", method: http.MethodPost, body: body,
+			signature: signTrelloWebhook("trello-secret", callbackURL, body), wantCode: http.StatusOK, wantSync: true,
+		},
+		{
+			name: "renamed card", method: http.MethodPost, body: renamed,
+			signature: signTrelloWebhook("trello-secret", callbackURL, renamed), wantCode: http.StatusOK, wantSync: true,
+		},
+		{
+			name: "card isn't found", method: http.MethodPost, body: deleted,
+			signature: signTrelloWebhook("trello-secret", callbackURL, deleted), wantCode: http.StatusOK, wantSync: true,
+		},
+		{
+			name: "card without key", method: http.MethodPost, body: withoutKey,
+			signature: signTrelloWebhook("trello-secret", callbackURL, withoutKey), wantCode: http.StatusOK,
+		},
+		{
+			name: "wrong secret", method: http.MethodPost, body: body,
+			signature: signTrelloWebhook("other", callbackURL, body), wantCode: http.StatusUnauthorized,
+		},
+		{name: "no signature", method: http.MethodPost, body: body, wantCode: http.StatusUnauthorized},
+		{name: "get", method: http.MethodGet, wantCode: http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var jTasks = map[string]*jira.Task{}
+			mustLoadJSONFile(t, "testdata/test_jira_tasks.json", &jTasks)
+
+			srv, jCli, tCli := getTestServer(t)
+			mockIssueQuery(jCli, jTasks)
+
+			cfg := tCli.GetConfig()
+			cfg.Secret = "trello-secret"
+			tCli.GetConfigFunc = func() *trello.Config {
+				return cfg
+			}
+
+			// Card is linked to the issue by marker, its name has no key.
+			tCli.GetCardFunc = func(ctx context.Context, cardID string) (*trello.Card, error) {
+				switch cardID {
+				case "098098098098098098098011":
+					return &trello.Card{ID: cardID, Name: "Renamed", Key: "JIRA1-984",
+						Marker: &trello.Marker{Key: "JIRA1-984"}}, nil
+				case "098098098098098098098098":
+					return &trello.Card{ID: cardID, Name: "X"}, nil
+				}
+
+				return nil, errors.New("card not found")
+			}
+
+			r := httptest.NewRequest(tt.method, trelloWebhookPath, bytes.NewReader(tt.body))
+			r.Header.Set("X-Trello-Webhook", tt.signature)
+
+			w := httptest.NewRecorder()
+			srv.handler(context.Background()).ServeHTTP(w, r)
+			require.Equal(t, tt.wantCode, w.Code)
+
+			if !tt.wantSync {
+				require.Empty(t, jCli.GetTasksCalls())
+
+				return
+			}
+
+			run := waitForIssueSync(t, srv, "JIRA1-984")
+			require.Empty(t, run.Result.Errors)
+			require.Equal(t, []*cardChange{{Key: "JIRA1-984", List: "Review"}}, run.Result.Moved)
+			require.Equal(t, []*cardChange{{Key: "JIRA1-984"}}, run.Result.Relabeled)
+
+			require.Len(t, jCli.GetTasksCalls(), 1)
+			require.Contains(t, jCli.GetTasksCalls()[0].Jql, `issuekey = "JIRA1-984" AND (`)
+			require.Equal(t, calls{{"098098098098098098098011", "12345678909876543219d1cc"}},
+				stringCalls(tCli.MoveCardToListCalls()))
+			require.Empty(t, tCli.CreateCardCalls())
+		})
+	}
+}
+
+func TestServer_handleJiraWebhook(t *testing.T) {
+	body, err := os.ReadFile("testdata/webhook_jira_issue_updated.json")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		path     string
+		body     []byte
+		wantCode int
+		wantSync bool
+	}{
+		{name: "issue done", path: jiraWebhookPath + "?secret=jira-secret", body: body, wantCode: http.StatusOK,
+			wantSync: true},
+		{name: "without issue", path: jiraWebhookPath + "?secret=jira-secret", body: []byte(`{}`),
+			wantCode: http.StatusOK},
+		{name: "invalid body", path: jiraWebhookPath + "?secret=jira-secret", body: []byte(`{`),
+			wantCode: http.StatusBadRequest},
+		{name: "wrong secret", path: jiraWebhookPath + "?secret=other", body: body, wantCode: http.StatusUnauthorized},
+		{name: "no secret", path: jiraWebhookPath, body: body, wantCode: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var jTasks = map[string]*jira.Task{}
+			mustLoadJSONFile(t, "testdata/test_jira_tasks.json", &jTasks)
+
+			srv, jCli, tCli := getTestServer(t)
+			mockIssueQuery(jCli, jTasks)
+
+			r := httptest.NewRequest(http.MethodPost, tt.path, bytes.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			srv.handler(context.Background()).ServeHTTP(w, r)
+			require.Equal(t, tt.wantCode, w.Code)
+
+			if !tt.wantSync {
+				require.Empty(t, jCli.GetTasksCalls())
+
+				return
+			}
+
+			// Issue isn't matched by sync query anymore, so its card is completed.
+			run := waitForIssueSync(t, srv, "JIRA1-390")
+			require.Empty(t, run.Result.Errors)
+			require.Equal(t, []*cardChange{{Key: "JIRA1-390", List: "Done"}}, run.Result.Moved)
+			require.Equal(t, calls{{"098098098098098098098018", "12345678909876543219d1cf"}},
+				stringCalls(tCli.MoveCardToListCalls()))
+			require.Empty(t, tCli.UpdateCardLabelsCalls())
+		})
+	}
+}
+
+func TestServer_startIssue(t *testing.T) {
+	var jTasks = map[string]*jira.Task{}
+	mustLoadJSONFile(t, "testdata/test_jira_tasks.json", &jTasks)
+
+	srv, jCli, _ := getTestServer(t)
+	mockIssueQuery(jCli, jTasks)
+
+	// Events of the issue waiting for sync are merged.
+	srv.mu.Lock()
+	srv.startIssue(context.Background(), "JIRA1-984")
+	srv.startIssue(context.Background(), "JIRA1-984")
+	srv.mu.Unlock()
+
+	waitForIssueSync(t, srv, "JIRA1-984")
+
+	srv.mu.Lock()
+	require.Len(t, jCli.GetTasksCalls(), 1)
+	srv.mu.Unlock()
+}
+
+func TestWebhookConfig_urls(t *testing.T) {
+	c := WebhookConfig{URL: "https://j2t.example.com/", Secret: "a&b"}
+
+	require.Equal(t, "https://j2t.example.com/webhooks/trello", c.trelloURL())
+	require.Equal(t, "https://j2t.example.com/webhooks/jira?secret=a%26b", c.jiraURL())
+	require.Equal(t, "https://j2t.example.com/webhooks/jira?secret=[REDACTED]", c.redact(c.jiraURL()))
+	require.Equal(t, "can't register a&b", WebhookConfig{}.redact("can't register a&b"))
+}
+
+func TestServer_registerWebhooks(t *testing.T) {
+	srv, jCli, tCli := getTestServer(t)
+
+	cfg := tCli.GetConfig()
+	cfg.Secret = "trello-secret"
+	tCli.GetConfigFunc = func() *trello.Config {
+		return cfg
+	}
+	tCli.RegisterWebhookFunc = func(ctx context.Context, s string) error {
+		return nil
+	}
+	jCli.RegisterWebhookFunc = func(ctx context.Context, name string, callbackURL string, jql string) error {
+		return nil
+	}
+	jCli.GetSelfFunc = func(ctx context.Context) (*jira.User, error) {
+		return &jira.User{Name: "5b10ac8d82e05b22cc7d4ef5", Email: "user@example.com"}, nil
+	}
+
+	srv.registerWebhooks(context.Background())
+
+	require.Len(t, tCli.RegisterWebhookCalls(), 1)
+	require.Equal(t, "https://j2t.example.com/webhooks/trello", tCli.RegisterWebhookCalls()[0].S)
+
+	require.Len(t, jCli.RegisterWebhookCalls(), 1)
+	require.Equal(t, "https://j2t.example.com/webhooks/jira?secret=jira-secret",
+		jCli.RegisterWebhookCalls()[0].CallbackURL)
+	// Webhook filter has issues once assigned to the user, so it sends events of done and reassigned issues
+	// to complete their cards.
+	require.Equal(t, `(assignee = "5b10ac8d82e05b22cc7d4ef5" AND status not in (done, closed, close, resolved)) `+
+		`OR assignee WAS "5b10ac8d82e05b22cc7d4ef5"`, jCli.RegisterWebhookCalls()[0].Jql)
+
+	// Webhooks aren't registered without secrets, requests couldn't be verified.
+	srv.webhooks.Secret = ""
+	cfg.Secret = ""
+
+	srv.registerWebhooks(context.Background())
+
+	require.Len(t, tCli.RegisterWebhookCalls(), 1)
+	require.Len(t, jCli.RegisterWebhookCalls(), 1)
+}
@@ -22,52 +22,106 @@ THE SOFTWARE.
 package app
 
 import (
+	"context"
+	"fmt"
 	"github.com/Brialius/jira2trello/internal/jira"
+	"github.com/Brialius/jira2trello/internal/theme"
 	"github.com/mattn/go-colorable"
 	"log"
+	"os"
+	"sort"
+	"time"
 )
 
-func WeeklyReport(jCli JiraConnector) {
-	if err := jCli.Connect(); err != nil {
+func WeeklyReport(ctx context.Context, jCli JiraConnector, rangeOpts DateRangeOptions, jql JQLConfig,
+	output string, th *theme.Theme, table TableOptions) {
+	t, err := newTaskTable(table)
+	if err != nil {
+		log.Fatalf("Can't create task table: %s", err)
+	}
+
+	dateRange, err := resolveDateRange(ctx, jCli, rangeOpts, time.Now())
+	if err != nil {
+		log.Fatalf("Can't get report date range: %s", err)
+	}
+
+	if err := jCli.Connect(ctx); err != nil {
 		log.Fatalf("Can't connect to jira server: %s", err)
 	}
 
-	tasks, err := weeklyReportJiraTasks(jCli)
+	tasks, err := weeklyReportJiraTasks(ctx, jCli, dateRange, jql)
 	if err != nil {
 		log.Fatalf("Can't get jira tasks: %s", err)
 	}
 
-	printJiraTasks(colorable.NewColorableStdout(), tasks)
+	// Filters are applied to json output too, columns and sorting are used only by the table.
+	now := time.Now()
+
+	for key, task := range tasks {
+		if !t.match(task, now) {
+			delete(tasks, key)
+		}
+	}
+
+	if output == OutputJSON {
+		r, err := newReport(ReportOptions{Weekly: true, Output: output}, dateRange, jiraTasksToTasks(tasks))
+		if err != nil {
+			log.Fatalf("Can't create report: %s", err)
+		}
+
+		if err := r.generate(os.Stdout); err != nil {
+			log.Fatalf("Can't generate report: %s", err)
+		}
+
+		return
+	}
+
+	fmt.Printf("Tasks for %s\n\n", dateRange)
+	t.print(colorable.NewColorableStdout(), th, tasks)
 }
 
-func weeklyReportJiraTasks(jCli JiraConnector) (map[string]*jira.Task, error) {
-	tasks, err := jCli.GetUserTasks("(resolutiondate > startOfDay(-7d) " +
-		"OR status not in (done, closed, close, resolved)) " +
-		"ORDER BY resolutiondate DESC")
+func weeklyReportJiraTasks(ctx context.Context, jCli JiraConnector, dateRange DateRange,
+	jql JQLConfig) (map[string]*jira.Task, error) {
+	query, err := jql.weeklyJQL(dateRange, "")
+	if err != nil {
+		return nil, err
+	}
 
-	return tasks, err
+	return jCli.GetTasks(ctx, query)
 }
 
-func WeeklyReportTasks(jCli JiraConnector) []*Task {
-	if err := jCli.Connect(); err != nil {
+func WeeklyReportTasks(ctx context.Context, jCli JiraConnector, dateRange DateRange, jql JQLConfig) []*Task {
+	if err := jCli.Connect(ctx); err != nil {
 		log.Fatalf("Can't connect to jira server: %s", err)
 	}
 
-	jTasks, err := weeklyReportJiraTasks(jCli)
+	jTasks, err := weeklyReportJiraTasks(ctx, jCli, dateRange, jql)
 	if err != nil {
 		log.Fatalf("Can't get jira tasks: %s", err)
 	}
 
+	return jiraTasksToTasks(jTasks)
+}
+
+func jiraTasksToTasks(jTasks map[string]*jira.Task) []*Task {
 	tasks := make([]*Task, 0, len(jTasks))
 
 	for _, jTask := range jTasks {
 		tasks = append(tasks, &Task{
-			Name:   jTask.Summary,
-			Status: jTask.Status,
-			Link:   jTask.Link,
-			Key:    jTask.Key,
+			Name:       jTask.Summary,
+			Status:     jTask.Status,
+			Link:       jTask.Link,
+			Key:        jTask.Key,
+			Type:       jTask.Type,
+			TimeSpent:  jTask.TimeSpent,
+			ParentKey:  jTask.ParentKey,
+			ParentLink: jTask.ParentLink,
 		})
 	}
 
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].Key < tasks[j].Key
+	})
+
 	return tasks
 }
@@ -22,13 +22,24 @@ THE SOFTWARE.
 package jira
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
+	"github.com/Brialius/jira2trello/internal/logging"
+	"github.com/Brialius/jira2trello/internal/metrics"
+	"github.com/Brialius/jira2trello/internal/retry"
 	"github.com/andygrunwald/go-jira"
-	"os"
+	"net/http"
+	"strings"
 	"time"
 )
 
+const (
+	defaultTimeout = 2 * time.Minute
+	// searchChunkSize is a number of keys searched by one `issuekey in (...)` query, Jira limits results per page.
+	searchChunkSize = 50
+)
+
 type Client struct {
 	*Config
 	cli *jira.Client
@@ -40,7 +51,9 @@ func NewClient(cfg *Config) *Client {
 	}
 }
 
-func (j *Client) Connect() error {
+func (j *Client) Connect(ctx context.Context) error {
+	logging.AddSecrets(j.Password, j.Token)
+
 	var (
 		client *jira.Client
 		err    error
@@ -48,14 +61,16 @@ func (j *Client) Connect() error {
 
 	if j.Token != "" {
 		tp := jira.PATAuthTransport{
-			Token: j.Token,
+			Token:     j.Token,
+			Transport: retry.NewTransport(metrics.NewTransport("jira", http.DefaultTransport), j.Retry),
 		}
 
 		client, err = jira.NewClient(tp.Client(), j.URL)
 	} else {
 		tp := jira.BasicAuthTransport{
-			Username: j.User,
-			Password: j.Password,
+			Username:  j.User,
+			Password:  j.Password,
+			Transport: retry.NewTransport(metrics.NewTransport("jira", http.DefaultTransport), j.Retry),
 		}
 
 		client, err = jira.NewClient(tp.Client(), j.URL)
@@ -71,9 +86,12 @@ func (j *Client) Connect() error {
 	return nil
 }
 
-func (j *Client) GetUserTasks(jql string) (map[string]*Task, error) {
+func (j *Client) GetTasks(ctx context.Context, jql string) (map[string]*Task, error) {
+	ctx, cancel := j.withTimeout(ctx)
+	defer cancel()
+
 	res := map[string]*Task{}
-	issues, _, err := j.cli.Issue.Search("assignee = currentUser() AND "+jql, nil)
+	issues, _, err := j.cli.Issue.SearchWithContext(ctx, jql, nil)
 
 	if err != nil {
 		// todo: error returned from external package is unwrapped
@@ -93,7 +111,10 @@ func (j *Client) GetUserTasks(jql string) (map[string]*Task, error) {
 			Status:    issue.Fields.Status.Name,
 			Desc:      issue.Fields.Description,
 			Type:      issue.Fields.Type.Name,
+			Assignee:  newUser(issue.Fields.Assignee),
+			Reporter:  newUser(issue.Fields.Reporter),
 		}
+		j.setTaskFields(res[issue.Key], issue.Fields)
 		if parent := issue.Fields.Parent; parent != nil {
 			res[issue.Key].ParentID = parent.ID
 			res[issue.Key].ParentKey = parent.Key
@@ -101,21 +122,230 @@ func (j *Client) GetUserTasks(jql string) (map[string]*Task, error) {
 		}
 	}
 
-	j.writeToJSONFile(res, "debug_jira_tasks.json")
+	j.writeToJSONFile(res, "jira_tasks.json")
 
 	return res, nil
 }
 
-func (j *Client) writeToJSONFile(value any, fileName string) {
-	if j.Debug {
-		const filePermissions = 0600
+// GetSelf returns current user.
+func (j *Client) GetSelf(ctx context.Context) (*User, error) {
+	ctx, cancel := j.withTimeout(ctx)
+	defer cancel()
+
+	self, _, err := j.cli.User.GetSelfWithContext(ctx)
+	if err != nil {
+		// todo: error returned from external package is unwrapped
+		return nil, err
+	}
+
+	return newUser(self), nil
+}
+
+// CreateTask creates issue assigned to current user.
+func (j *Client) CreateTask(ctx context.Context, project, issueType, summary, desc string) (*Task, error) {
+	ctx, cancel := j.withTimeout(ctx)
+	defer cancel()
+
+	self, _, err := j.cli.User.GetSelfWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can't get current user: %w", err)
+	}
+
+	issue, _, err := j.cli.Issue.CreateWithContext(ctx, &jira.Issue{
+		Fields: &jira.IssueFields{
+			Project:     jira.Project{Key: project},
+			Type:        jira.IssueType{Name: issueType},
+			Summary:     summary,
+			Description: desc,
+			Assignee:    &jira.User{Name: self.Name, AccountID: self.AccountID},
+		},
+	})
+	if err != nil {
+		// todo: error returned from external package is unwrapped
+		return nil, err
+	}
+
+	return &Task{
+		Summary:  summary,
+		Link:     j.URL + "/browse/" + issue.Key,
+		Self:     issue.Self,
+		Key:      issue.Key,
+		Desc:     desc,
+		Type:     issueType,
+		Assignee: newUser(self),
+	}, nil
+}
+
+// GetExistingKeys returns keys of the issues, which exist in Jira and are visible for current user.
+func (j *Client) GetExistingKeys(ctx context.Context, keys []string) (map[string]bool, error) {
+	ctx, cancel := j.withTimeout(ctx)
+	defer cancel()
 
-		//nolint:errchkjson
-		b, _ := json.MarshalIndent(value, "", "  ")
-		err := os.WriteFile(fileName, b, filePermissions)
+	res := map[string]bool{}
 
+	for start := 0; start < len(keys); start += searchChunkSize {
+		end := min(start+searchChunkSize, len(keys))
+
+		// Query isn't validated strictly, so missing keys don't fail the search.
+		issues, _, err := j.cli.Issue.SearchWithContext(ctx,
+			fmt.Sprintf("issuekey in (%s)", strings.Join(keys[start:end], ", ")),
+			&jira.SearchOptions{Fields: []string{"key"}, MaxResults: end - start, ValidateQuery: "warn"})
 		if err != nil {
-			fmt.Printf("can't write debug file: %s", err)
+			// todo: error returned from external package is unwrapped
+			return nil, err
+		}
+
+		for _, issue := range issues {
+			res[issue.Key] = true
 		}
 	}
+
+	return res, nil
+}
+
+// GetWatchers returns users watching the issue.
+func (j *Client) GetWatchers(ctx context.Context, key string) ([]*User, error) {
+	ctx, cancel := j.withTimeout(ctx)
+	defer cancel()
+
+	watchers, _, err := j.cli.Issue.GetWatchersWithContext(ctx, key)
+	if err != nil {
+		// todo: error returned from external package is unwrapped
+		return nil, err
+	}
+
+	res := make([]*User, 0, len(*watchers))
+
+	for i := range *watchers {
+		res = append(res, newUser(&(*watchers)[i]))
+	}
+
+	return res, nil
+}
+
+func newUser(user *jira.User) *User {
+	if user == nil {
+		return nil
+	}
+
+	name := user.Name
+	if name == "" {
+		name = user.AccountID
+	}
+
+	return &User{
+		Name:        name,
+		DisplayName: user.DisplayName,
+		Email:       user.EmailAddress,
+	}
+}
+
+// GetLastSprint returns the most recently completed sprint of configured board.
+func (j *Client) GetLastSprint(ctx context.Context) (*Sprint, error) {
+	if j.BoardID == 0 {
+		return nil, errors.New("jira board id is not configured")
+	}
+
+	ctx, cancel := j.withTimeout(ctx)
+	defer cancel()
+
+	var last *Sprint
+
+	opts := &jira.GetAllSprintsOptions{State: "closed"}
+
+	for {
+		sprints, _, err := j.cli.Board.GetAllSprintsWithOptionsWithContext(ctx, j.BoardID, opts)
+		if err != nil {
+			// todo: error returned from external package is unwrapped
+			return nil, err
+		}
+
+		for _, sprint := range sprints.Values {
+			if sprint.StartDate == nil {
+				continue
+			}
+
+			end := sprint.CompleteDate
+			if end == nil {
+				end = sprint.EndDate
+			}
+
+			if end == nil || (last != nil && !end.After(last.End)) {
+				continue
+			}
+
+			last = &Sprint{
+				ID:    sprint.ID,
+				Name:  sprint.Name,
+				Start: *sprint.StartDate,
+				End:   *end,
+			}
+		}
+
+		if sprints.IsLast || len(sprints.Values) == 0 {
+			break
+		}
+
+		opts.StartAt += len(sprints.Values)
+	}
+
+	if last == nil {
+		return nil, fmt.Errorf("no closed sprints found on board %d", j.BoardID)
+	}
+
+	return last, nil
+}
+
+// ValidateJQL checks query with jql/parse endpoint,
+// search is used as a fallback for Jira Server, which doesn't provide the endpoint.
+func (j *Client) ValidateJQL(ctx context.Context, jql string) error {
+	ctx, cancel := j.withTimeout(ctx)
+	defer cancel()
+
+	req, err := j.cli.NewRequestWithContext(ctx, http.MethodPost, "rest/api/2/jql/parse?validation=strict",
+		map[string][]string{"queries": {jql}})
+	if err != nil {
+		return err
+	}
+
+	var res struct {
+		Queries []struct {
+			Errors []string `json:"errors"`
+		} `json:"queries"`
+	}
+
+	resp, err := j.cli.Do(req, &res)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			_, _, err = j.cli.Issue.SearchWithContext(ctx, jql, &jira.SearchOptions{MaxResults: 1, ValidateQuery: "strict"})
+		}
+
+		// todo: error returned from external package is unwrapped
+		return err
+	}
+
+	for _, query := range res.Queries {
+		if len(query.Errors) > 0 {
+			return errors.New(strings.Join(query.Errors, "; "))
+		}
+	}
+
+	return nil
+}
+
+// withTimeout limits API call by configured timeout, which includes retries.
+func (j *Client) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := j.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// writeToJSONFile writes debug file, when debug is enabled.
+func (j *Client) writeToJSONFile(value any, fileName string) {
+	if j.Debug {
+		logging.Dump(fileName, value)
+	}
 }
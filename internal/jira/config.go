@@ -1,9 +1,40 @@
 package jira
 
+import (
+	"github.com/Brialius/jira2trello/internal/retry"
+	"time"
+)
+
 type Config struct {
 	User     string
 	Password string
 	Token    string
 	URL      string
-	Debug    bool
+	// BoardID is an agile board, which sprints are taken from.
+	BoardID int
+	// Fields are IDs of custom fields, which differ between Jira sites.
+	Fields *Fields
+	// Promote configures issues created from Trello cards.
+	Promote *Promote
+	// Timeout limits each API call including retries, default is 2 minutes.
+	Timeout time.Duration
+	// Retry configures retries of failed API requests, defaults are used if it's not set.
+	Retry *retry.Policy
+	Debug bool
+}
+
+type Fields struct {
+	// StoryPoints is custom field ID of story points, e.g. customfield_10016.
+	StoryPoints string
+	// Sprint is custom field ID of sprint, e.g. customfield_10020.
+	Sprint string
+	// EpicLink is custom field ID of epic link on Jira server, e.g. customfield_10101.
+	EpicLink string
+}
+
+type Promote struct {
+	// Project is a key of Jira project.
+	Project string
+	// IssueType is a name of issue type, e.g. Task.
+	IssueType string
 }
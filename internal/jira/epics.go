@@ -0,0 +1,163 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"github.com/andygrunwald/go-jira"
+	"sort"
+	"strings"
+)
+
+const epicType = "Epic"
+
+// issueInfo keeps fields of parent issues used to resolve epics.
+type issueInfo struct {
+	Type      string
+	Summary   string
+	EpicKey   string
+	ParentKey string
+}
+
+// ResolveEpics sets epic of the tasks, which have no Epic Link custom field set (it's used on Jira server),
+// epic is the parent of type Epic. Epic of sub-task is the epic of its parent.
+func (j *Client) ResolveEpics(ctx context.Context, tasks map[string]*Task) error {
+	ctx, cancel := j.withTimeout(ctx)
+	defer cancel()
+
+	parents := map[string]bool{}
+
+	for _, task := range tasks {
+		if task.EpicKey == "" && task.ParentKey != "" {
+			parents[task.ParentKey] = true
+		}
+	}
+
+	info, err := j.getIssueInfo(ctx, keysOf(parents))
+	if err != nil {
+		return fmt.Errorf("can't get parent issues: %w", err)
+	}
+
+	// Parent of sub-task is a story, so its epic is taken from the story.
+	grandparents := map[string]bool{}
+
+	for _, task := range tasks {
+		parent, ok := info[task.ParentKey]
+		if task.EpicKey != "" || !ok {
+			continue
+		}
+
+		switch {
+		case parent.Type == epicType:
+			task.EpicKey = task.ParentKey
+		case parent.EpicKey != "":
+			task.EpicKey = parent.EpicKey
+		case parent.ParentKey != "":
+			grandparents[parent.ParentKey] = true
+		}
+	}
+
+	missing := map[string]bool{}
+
+	for _, task := range tasks {
+		if _, ok := info[task.EpicKey]; task.EpicKey != "" && !ok {
+			missing[task.EpicKey] = true
+		}
+	}
+
+	for key := range grandparents {
+		if _, ok := info[key]; !ok {
+			missing[key] = true
+		}
+	}
+
+	more, err := j.getIssueInfo(ctx, keysOf(missing))
+	if err != nil {
+		return fmt.Errorf("can't get epics: %w", err)
+	}
+
+	for key, issue := range more {
+		info[key] = issue
+	}
+
+	for _, task := range tasks {
+		if parent, ok := info[task.ParentKey]; ok && task.EpicKey == "" && parent.ParentKey != "" {
+			if grandparent, ok := info[parent.ParentKey]; ok && grandparent.Type == epicType {
+				task.EpicKey = parent.ParentKey
+			}
+		}
+
+		if task.EpicKey != "" {
+			task.EpicLink = j.URL + "/browse/" + task.EpicKey
+
+			// Epic isn't returned if it isn't visible to the user.
+			if epic, ok := info[task.EpicKey]; ok {
+				task.EpicName = epic.Summary
+			}
+		}
+	}
+
+	return nil
+}
+
+func (j *Client) epicLinkOf(fields *jira.IssueFields) string {
+	if fields == nil || j.Fields == nil || j.Fields.EpicLink == "" {
+		return ""
+	}
+
+	key, _ := fields.Unknowns[j.Fields.EpicLink].(string)
+
+	return key
+}
+
+func (j *Client) getIssueInfo(ctx context.Context, keys []string) (map[string]*issueInfo, error) {
+	res := map[string]*issueInfo{}
+
+	if len(keys) == 0 {
+		return res, nil
+	}
+
+	fields := []string{"summary", "issuetype", "parent"}
+	if j.Fields != nil && j.Fields.EpicLink != "" {
+		fields = append(fields, j.Fields.EpicLink)
+	}
+
+	for start := 0; start < len(keys); start += searchChunkSize {
+		end := min(start+searchChunkSize, len(keys))
+
+		// Query isn't validated strictly, so keys of missing or hidden issues don't fail the search.
+		issues, _, err := j.cli.Issue.SearchWithContext(ctx,
+			fmt.Sprintf("issuekey in (%s)", strings.Join(keys[start:end], ", ")),
+			&jira.SearchOptions{Fields: fields, MaxResults: end - start, ValidateQuery: "warn"})
+		if err != nil {
+			// todo: error returned from external package is unwrapped
+			return nil, err
+		}
+
+		for i := range issues {
+			issue := &issues[i]
+			res[issue.Key] = &issueInfo{
+				Type:    issue.Fields.Type.Name,
+				Summary: issue.Fields.Summary,
+				EpicKey: j.epicLinkOf(issue.Fields),
+			}
+
+			if issue.Fields.Parent != nil {
+				res[issue.Key].ParentKey = issue.Fields.Parent.Key
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func keysOf(m map[string]bool) []string {
+	res := make([]string, 0, len(m))
+
+	for key := range m {
+		res = append(res, key)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
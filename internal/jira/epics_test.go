@@ -0,0 +1,109 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/andygrunwald/go-jira"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestClient_ResolveEpics(t *testing.T) {
+	issues := map[string]map[string]any{
+		"EP-1":  {"summary": "Epic one", "issuetype": map[string]any{"name": "Epic"}},
+		"ST-1":  {"summary": "Story", "issuetype": map[string]any{"name": "Story"}, "parent": map[string]any{"key": "EP-1"}},
+		"ST-2":  {"summary": "Story", "issuetype": map[string]any{"name": "Story"}, "customfield_1": "EP-2"},
+		"EP-2":  {"summary": "Epic two", "issuetype": map[string]any{"name": "Epic"}},
+		"ST-3":  {"summary": "Story", "issuetype": map[string]any{"name": "Story"}},
+		"EP-3":  {"summary": "Epic three", "issuetype": map[string]any{"name": "Epic"}},
+		"TSK-1": {"summary": "Task", "issuetype": map[string]any{"name": "Task"}},
+	}
+
+	keyRe := regexp.MustCompile(`[A-Z]+-[0-9]+`)
+	requests := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		require.Equal(t, "warn", r.URL.Query().Get("validateQuery"))
+
+		// Issues, which aren't visible to the user, aren't returned.
+		res := make([]map[string]any, 0)
+		for _, key := range keyRe.FindAllString(r.URL.Query().Get("jql"), -1) {
+			if fields, ok := issues[key]; ok {
+				res = append(res, map[string]any{"key": key, "fields": fields})
+			}
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"issues": res, "total": len(res)}))
+	}))
+	defer srv.Close()
+
+	cli, err := jira.NewClient(nil, srv.URL)
+	require.NoError(t, err)
+
+	j := &Client{Config: &Config{URL: "https://jira-site", Fields: &Fields{EpicLink: "customfield_1"}}, cli: cli}
+
+	tasks := map[string]*Task{
+		"T-1": {Key: "T-1", ParentKey: "EP-1"},
+		"T-2": {Key: "T-2", ParentKey: "ST-1"},
+		"T-3": {Key: "T-3", ParentKey: "ST-2"},
+		"T-4": {Key: "T-4", EpicKey: "EP-3"},
+		"T-5": {Key: "T-5", ParentKey: "ST-3"},
+		"T-6": {Key: "T-6", ParentKey: "TSK-1"},
+		"T-7": {Key: "T-7"},
+		"T-8": {Key: "T-8", EpicKey: "EP-9"},
+		"T-9": {Key: "T-9", ParentKey: "ST-9"},
+	}
+
+	require.NoError(t, j.ResolveEpics(context.Background(), tasks))
+	require.Equal(t, 2, requests)
+
+	want := map[string][2]string{
+		"T-1": {"EP-1", "Epic one"},
+		"T-2": {"EP-1", "Epic one"},
+		"T-3": {"EP-2", "Epic two"},
+		"T-4": {"EP-3", "Epic three"},
+		"T-5": {"", ""},
+		"T-6": {"", ""},
+		"T-7": {"", ""},
+		"T-8": {"EP-9", ""},
+		"T-9": {"", ""},
+	}
+
+	for key, task := range tasks {
+		require.Equal(t, want[key], [2]string{task.EpicKey, task.EpicName}, key)
+	}
+
+	require.Equal(t, "https://jira-site/browse/EP-1", tasks["T-1"].EpicLink)
+}
+
+func TestClient_getIssueInfo(t *testing.T) {
+	var maxResults []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		maxResults = append(maxResults, r.URL.Query().Get("maxResults"))
+
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"issues": []any{}}))
+	}))
+	defer srv.Close()
+
+	cli, err := jira.NewClient(nil, srv.URL)
+	require.NoError(t, err)
+
+	j := &Client{Config: &Config{}, cli: cli}
+
+	keys := make([]string, 0, 120)
+	for i := 0; i < 120; i++ {
+		keys = append(keys, fmt.Sprintf("T-%d", i))
+	}
+
+	info, err := j.getIssueInfo(context.Background(), keys)
+	require.NoError(t, err)
+	require.Empty(t, info)
+	require.Equal(t, []string{"50", "50", "20"}, maxResults)
+}
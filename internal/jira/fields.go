@@ -0,0 +1,64 @@
+package jira
+
+import (
+	"github.com/andygrunwald/go-jira"
+	"regexp"
+)
+
+// sprintNameRe matches sprint name in the sprint field of Jira server, which is a string like
+// com.atlassian.greenhopper.service.sprint.Sprint@1[id=1,rapidViewId=1,state=ACTIVE,name=Sprint 1,...].
+var sprintNameRe = regexp.MustCompile(`[\[,]name=([^,\]]*)`)
+
+func (j *Client) setTaskFields(task *Task, fields *jira.IssueFields) {
+	if fields.Priority != nil {
+		task.Priority = fields.Priority.Name
+	}
+
+	for _, version := range fields.FixVersions {
+		task.FixVersions = append(task.FixVersions, version.Name)
+	}
+
+	for _, component := range fields.Components {
+		task.Components = append(task.Components, component.Name)
+	}
+
+	task.Labels = fields.Labels
+
+	if j.Fields == nil {
+		return
+	}
+
+	if j.Fields.StoryPoints != "" {
+		if points, ok := fields.Unknowns[j.Fields.StoryPoints].(float64); ok {
+			task.StoryPoints = points
+		}
+	}
+
+	if j.Fields.Sprint != "" {
+		task.Sprint = sprintName(fields.Unknowns[j.Fields.Sprint])
+	}
+
+	task.EpicKey = j.epicLinkOf(fields)
+}
+
+// sprintName returns name of the last sprint of the task, sprint field is a list of objects on Jira cloud
+// and a list of strings on Jira server.
+func sprintName(field any) string {
+	sprints, ok := field.([]any)
+	if !ok || len(sprints) == 0 {
+		return ""
+	}
+
+	switch sprint := sprints[len(sprints)-1].(type) {
+	case map[string]any:
+		name, _ := sprint["name"].(string)
+
+		return name
+	case string:
+		if match := sprintNameRe.FindStringSubmatch(sprint); match != nil {
+			return match[1]
+		}
+	}
+
+	return ""
+}
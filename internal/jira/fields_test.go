@@ -0,0 +1,86 @@
+package jira
+
+import (
+	"github.com/andygrunwald/go-jira"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestClient_setTaskFields(t *testing.T) {
+	fields := &jira.IssueFields{
+		Priority:    &jira.Priority{Name: "High"},
+		FixVersions: []*jira.FixVersion{{Name: "1.0"}, {Name: "1.1"}},
+		Components:  []*jira.Component{{Name: "API"}},
+		Labels:      []string{"backend"},
+		Unknowns: map[string]any{
+			"customfield_10016": 5.0,
+			"customfield_10020": []any{
+				map[string]any{"id": 1.0, "name": "Sprint 1"},
+				map[string]any{"id": 2.0, "name": "Sprint 2"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		fields *Fields
+		want   *Task
+	}{
+		{
+			name:   "custom fields",
+			fields: &Fields{StoryPoints: "customfield_10016", Sprint: "customfield_10020"},
+			want: &Task{
+				Priority: "High", StoryPoints: 5, Sprint: "Sprint 2",
+				FixVersions: []string{"1.0", "1.1"}, Components: []string{"API"}, Labels: []string{"backend"},
+			},
+		},
+		{
+			name: "custom fields aren't configured",
+			want: &Task{
+				Priority:    "High",
+				FixVersions: []string{"1.0", "1.1"}, Components: []string{"API"}, Labels: []string{"backend"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Client{Config: &Config{Fields: tt.fields}}
+			task := &Task{}
+			j.setTaskFields(task, fields)
+			require.Equal(t, tt.want, task)
+		})
+	}
+}
+
+func Test_sprintName(t *testing.T) {
+	tests := []struct {
+		name  string
+		field any
+		want  string
+	}{
+		{
+			name:  "jira cloud",
+			field: []any{map[string]any{"name": "Sprint 7"}},
+			want:  "Sprint 7",
+		},
+		{
+			name: "jira server",
+			field: []any{
+				"com.atlassian.greenhopper.service.sprint.Sprint@1a2b[id=7,rapidViewId=1,state=ACTIVE,name=Sprint 7,startDate=]",
+			},
+			want: "Sprint 7",
+		},
+		{
+			name: "no sprint",
+		},
+		{
+			name:  "empty",
+			field: []any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, sprintName(tt.field))
+		})
+	}
+}
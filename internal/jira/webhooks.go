@@ -0,0 +1,110 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+)
+
+const webhooksPath = "rest/webhooks/1.0/webhook"
+
+// webhookEvents are issue events sent to jira2trello webhook.
+var webhookEvents = []string{"jira:issue_created", "jira:issue_updated", "jira:issue_deleted"}
+
+type webhook struct {
+	Self    string            `json:"self,omitempty"`
+	Name    string            `json:"name"`
+	URL     string            `json:"url"`
+	Events  []string          `json:"events,omitempty"`
+	Filters map[string]string `json:"filters,omitempty"`
+}
+
+// RegisterWebhook registers webhook of issue events, which are sent for issues matched by JQL.
+// Webhook with the same URL up to query, e.g. with other secret, is updated if it's already registered,
+// other webhooks with the URL are deleted. Registration requires Jira admin permission.
+func (j *Client) RegisterWebhook(ctx context.Context, name, callbackURL, jql string) error {
+	ctx, cancel := j.withTimeout(ctx)
+	defer cancel()
+
+	req, err := j.cli.NewRequestWithContext(ctx, http.MethodGet, webhooksPath, nil)
+	if err != nil {
+		return fmt.Errorf("can't create request: %w", err)
+	}
+
+	var webhooks []*webhook
+	if _, err := j.cli.Do(req, &webhooks); err != nil {
+		return fmt.Errorf("can't get webhooks: %w", err)
+	}
+
+	hook := &webhook{
+		Name:    name,
+		URL:     callbackURL,
+		Events:  webhookEvents,
+		Filters: map[string]string{"issue-related-events-section": jql},
+	}
+
+	var registered *webhook
+
+	for _, w := range webhooks {
+		if withoutQuery(w.URL) != withoutQuery(callbackURL) {
+			continue
+		}
+
+		if registered == nil {
+			registered = w
+
+			continue
+		}
+
+		// Webhooks left by registrations with previous secret are deleted, so events aren't sent twice.
+		if err := j.webhookRequest(ctx, http.MethodDelete, w, nil); err != nil {
+			return fmt.Errorf("can't delete webhook: %w", err)
+		}
+	}
+
+	if registered != nil {
+		if registered.URL == callbackURL && registered.Filters["issue-related-events-section"] == jql {
+			return nil
+		}
+
+		if err := j.webhookRequest(ctx, http.MethodPut, registered, hook); err != nil {
+			return fmt.Errorf("can't update webhook: %w", err)
+		}
+
+		return nil
+	}
+
+	req, err = j.cli.NewRequestWithContext(ctx, http.MethodPost, webhooksPath, hook)
+	if err != nil {
+		return fmt.Errorf("can't create request: %w", err)
+	}
+
+	if _, err := j.cli.Do(req, nil); err != nil {
+		return fmt.Errorf("can't create webhook: %w", err)
+	}
+
+	return nil
+}
+
+// webhookRequest sends request to the registered webhook.
+func (j *Client) webhookRequest(ctx context.Context, method string, w *webhook, body any) error {
+	req, err := j.cli.NewRequestWithContext(ctx, method, webhooksPath+"/"+path.Base(w.Self), body)
+	if err != nil {
+		return fmt.Errorf("can't create request: %w", err)
+	}
+
+	if _, err := j.cli.Do(req, nil); err != nil {
+		// todo: error returned from external package is unwrapped
+		return err
+	}
+
+	return nil
+}
+
+func withoutQuery(callbackURL string) string {
+	u, _, _ := strings.Cut(callbackURL, "?")
+
+	return u
+}
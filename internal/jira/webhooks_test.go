@@ -0,0 +1,98 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/andygrunwald/go-jira"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_RegisterWebhook(t *testing.T) {
+	const (
+		registered = `{"self":"https://jira-site/rest/webhooks/1.0/webhook/12","name":"jira2trello",` +
+			`"url":"https://j2t.example.com/webhooks/jira?secret=s",` +
+			`"filters":{"issue-related-events-section":"assignee = \"user\""}}`
+		stale = `{"self":"https://jira-site/rest/webhooks/1.0/webhook/13","name":"jira2trello",` +
+			`"url":"https://j2t.example.com/webhooks/jira?secret=old"}`
+	)
+
+	tests := []struct {
+		name        string
+		webhooks    string
+		callbackURL string
+		jql         string
+		want        []string
+	}{
+		{
+			name: "registered", webhooks: "[" + registered + "]",
+			callbackURL: "https://j2t.example.com/webhooks/jira?secret=s", jql: `assignee = "user"`,
+			want: []string{},
+		},
+		{
+			name: "new", webhooks: "[" + registered + "]",
+			callbackURL: "https://other.example.com/webhooks/jira?secret=s", jql: `assignee = "user"`,
+			want: []string{http.MethodPost + " /" + webhooksPath},
+		},
+		{
+			name: "changed query", webhooks: "[" + registered + "]",
+			callbackURL: "https://j2t.example.com/webhooks/jira?secret=s", jql: `assignee = "other"`,
+			want: []string{http.MethodPut + " /" + webhooksPath + "/12"},
+		},
+		{
+			name: "changed secret", webhooks: "[" + registered + "," + stale + "]",
+			callbackURL: "https://j2t.example.com/webhooks/jira?secret=new", jql: `assignee = "user"`,
+			want: []string{
+				http.MethodDelete + " /" + webhooksPath + "/13",
+				http.MethodPut + " /" + webhooksPath + "/12",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := make([]string, 0)
+			sent := make([]*webhook, 0)
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					require.Equal(t, "/"+webhooksPath, r.URL.Path)
+					_, _ = w.Write([]byte(tt.webhooks))
+
+					return
+				}
+
+				requests = append(requests, r.Method+" "+r.URL.Path)
+
+				if r.Method == http.MethodDelete {
+					return
+				}
+
+				var hook webhook
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&hook))
+				sent = append(sent, &hook)
+			}))
+			defer srv.Close()
+
+			cli, err := jira.NewClient(nil, srv.URL)
+			require.NoError(t, err)
+
+			j := &Client{Config: &Config{}, cli: cli}
+
+			require.NoError(t, j.RegisterWebhook(context.Background(), "jira2trello", tt.callbackURL, tt.jql))
+			require.Equal(t, tt.want, requests)
+
+			if len(sent) == 0 {
+				return
+			}
+
+			require.Equal(t, []*webhook{{
+				Name:    "jira2trello",
+				URL:     tt.callbackURL,
+				Events:  webhookEvents,
+				Filters: map[string]string{"issue-related-events-section": tt.jql},
+			}}, sent)
+		})
+	}
+}
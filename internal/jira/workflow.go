@@ -0,0 +1,63 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"github.com/andygrunwald/go-jira"
+	"time"
+)
+
+// GetTransitions returns workflow transitions available for the issue.
+func (j *Client) GetTransitions(ctx context.Context, key string) ([]*Transition, error) {
+	ctx, cancel := j.withTimeout(ctx)
+	defer cancel()
+
+	transitions, _, err := j.cli.Issue.GetTransitionsWithContext(ctx, key)
+	if err != nil {
+		// todo: error returned from external package is unwrapped
+		return nil, err
+	}
+
+	res := make([]*Transition, 0, len(transitions))
+
+	for _, t := range transitions {
+		res = append(res, &Transition{ID: t.ID, Name: t.Name, To: t.To.Name})
+	}
+
+	return res, nil
+}
+
+// DoTransition moves the issue along workflow transition.
+func (j *Client) DoTransition(ctx context.Context, key, transitionID string) error {
+	ctx, cancel := j.withTimeout(ctx)
+	defer cancel()
+
+	if _, err := j.cli.Issue.DoTransitionWithContext(ctx, key, transitionID); err != nil {
+		// todo: error returned from external package is unwrapped
+		return err
+	}
+
+	return nil
+}
+
+// AddWorklog logs time spent on the issue starting now, Jira counts it in whole minutes.
+func (j *Client) AddWorklog(ctx context.Context, key string, spent time.Duration) error {
+	if spent < time.Minute {
+		return errors.New("time spent should be at least 1 minute")
+	}
+
+	ctx, cancel := j.withTimeout(ctx)
+	defer cancel()
+
+	started := jira.Time(time.Now())
+
+	if _, _, err := j.cli.Issue.AddWorklogRecordWithContext(ctx, key, &jira.WorklogRecord{
+		Started:          &started,
+		TimeSpentSeconds: int(spent.Seconds()),
+	}); err != nil {
+		// todo: error returned from external package is unwrapped
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,241 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxDumpSize limits size of debug file, bigger payloads are truncated.
+	maxDumpSize = 10 << 20
+
+	redacted = "[REDACTED]"
+)
+
+// sensitiveKeys are JSON keys, which values are redacted in debug files.
+var sensitiveKeys = map[string]bool{
+	"apikey":        true,
+	"api_key":       true,
+	"token":         true,
+	"accesstoken":   true,
+	"password":      true,
+	"secret":        true,
+	"authorization": true,
+}
+
+var defaultDumper = &dumper{}
+
+// dumper writes debug files to directory created on the first write.
+type dumper struct {
+	mu sync.Mutex
+	// baseDir is a parent of run directories, user cache dir is used if it's empty.
+	baseDir string
+	dir     string
+	failed  bool
+	secrets map[string]bool
+	maxSize int
+}
+
+// Dump writes value as JSON file to debug directory of the run, which is created in user cache dir.
+// Tokens and passwords are redacted, files bigger than 10 MiB are truncated: arrays keep first items,
+// other values are replaced by marker with the original size.
+func Dump(name string, value any) {
+	defaultDumper.dump(name, value)
+}
+
+// AddSecrets registers values, which are redacted in debug files wherever they are found.
+func AddSecrets(secrets ...string) {
+	defaultDumper.addSecrets(secrets...)
+}
+
+func (d *dumper) addSecrets(secrets ...string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.secrets == nil {
+		d.secrets = map[string]bool{}
+	}
+
+	// Clients add their secrets on each connection, so secrets are kept in set.
+	for _, secret := range secrets {
+		if secret != "" {
+			d.secrets[secret] = true
+		}
+	}
+}
+
+func (d *dumper) dump(name string, value any) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	dir, err := d.runDir()
+	if err != nil {
+		if !d.failed {
+			slog.Warn("Can't create debug directory", "error", err)
+			d.failed = true
+		}
+
+		return
+	}
+
+	doc, err := d.document(value)
+	if err != nil {
+		slog.Warn("Can't encode debug file", "file", name, "error", err)
+
+		return
+	}
+
+	b, err := d.encode(doc)
+	if err != nil {
+		slog.Warn("Can't encode debug file", "file", name, "error", err)
+
+		return
+	}
+
+	maxSize := d.maxSize
+	if maxSize == 0 {
+		maxSize = maxDumpSize
+	}
+
+	if len(b) > maxSize {
+		slog.Warn("Debug file is truncated", "file", name, "size", len(b), "limit", maxSize)
+
+		if b, err = d.truncate(doc, len(b), maxSize); err != nil {
+			slog.Warn("Can't encode debug file", "file", name, "error", err)
+
+			return
+		}
+	}
+
+	const filePermissions = 0600
+
+	if err := os.WriteFile(filepath.Join(dir, name), b, filePermissions); err != nil {
+		slog.Warn("Can't write debug file", "file", name, "error", err)
+	}
+}
+
+// runDir returns debug directory of the run, it's named by start time, so files of previous runs are kept.
+func (d *dumper) runDir() (string, error) {
+	if d.dir != "" {
+		return d.dir, nil
+	}
+
+	base := d.baseDir
+	if base == "" {
+		cache, err := os.UserCacheDir()
+		if err != nil {
+			return "", fmt.Errorf("can't find user cache dir: %w", err)
+		}
+
+		base = filepath.Join(cache, "jira2trello", "debug")
+	}
+
+	const dirPermissions = 0700
+
+	dir := filepath.Join(base, time.Now().Format("20060102-150405.000"))
+	if err := os.MkdirAll(dir, dirPermissions); err != nil {
+		return "", fmt.Errorf("can't create directory: %w", err)
+	}
+
+	slog.Info("Writing debug files", "dir", dir)
+
+	d.dir = dir
+
+	return dir, nil
+}
+
+// document returns JSON document of the value with values of sensitive keys and secrets redacted.
+// Secrets are redacted in decoded strings, so they are found even if they are escaped in JSON.
+func (d *dumper) document(value any) (any, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("can't marshal value: %w", err)
+	}
+
+	var doc any
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("can't unmarshal value: %w", err)
+	}
+
+	return redact(doc, d.secretsReplacer()), nil
+}
+
+// secretsReplacer returns replacer of secrets, longer secrets are replaced first, so secret containing
+// another one is redacted entirely.
+func (d *dumper) secretsReplacer() *strings.Replacer {
+	secrets := make([]string, 0, len(d.secrets))
+	for secret := range d.secrets {
+		secrets = append(secrets, secret)
+	}
+
+	sort.Slice(secrets, func(i, j int) bool {
+		return len(secrets[i]) > len(secrets[j])
+	})
+
+	pairs := make([]string, 0, 2*len(secrets))
+	for _, secret := range secrets {
+		pairs = append(pairs, secret, redacted)
+	}
+
+	return strings.NewReplacer(pairs...)
+}
+
+// encode returns indented JSON of the document.
+func (d *dumper) encode(doc any) ([]byte, error) {
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("can't marshal value: %w", err)
+	}
+
+	return b, nil
+}
+
+// truncate returns marker of truncated document, which still parses as JSON. Marker keeps as many first items
+// of array as fit into maxSize.
+func (d *dumper) truncate(doc any, size, maxSize int) ([]byte, error) {
+	items, _ := doc.([]any)
+
+	for len(items) > 0 {
+		items = items[:len(items)/2]
+
+		b, err := d.encode(map[string]any{"truncated": true, "size": size, "items": items})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(b) <= maxSize {
+			return b, nil
+		}
+	}
+
+	return d.encode(map[string]any{"truncated": true, "size": size})
+}
+
+// redact replaces values of sensitive keys and secrets in strings of decoded JSON document.
+func redact(value any, secrets *strings.Replacer) any {
+	switch v := value.(type) {
+	case string:
+		return secrets.Replace(v)
+	case map[string]any:
+		for key, item := range v {
+			if sensitiveKeys[strings.ToLower(key)] {
+				v[key] = redacted
+			} else {
+				v[key] = redact(item, secrets)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redact(item, secrets)
+		}
+	}
+
+	return value
+}
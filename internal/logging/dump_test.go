@@ -0,0 +1,80 @@
+package logging
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDumper_dump(t *testing.T) {
+	base := t.TempDir()
+	d := &dumper{baseDir: base, maxSize: 200}
+	d.addSecrets("secret-token", "", "a/b+c<d>é")
+
+	d.dump("cards.json", map[string]any{
+		"key":   "K-1",
+		"desc":  "url?token=secret-token",
+		"Token": "other-token",
+		"items": []any{map[string]any{"password": "p"}},
+		"body":  json.RawMessage(`{"token_value": "a\/b\u002bc\u003cd\u003e\u00e9"}`),
+	})
+	d.dump("big.json", strings.Repeat("x", 300))
+
+	dirs, err := os.ReadDir(base)
+	require.NoError(t, err)
+	require.Len(t, dirs, 1)
+
+	b, err := os.ReadFile(filepath.Join(d.dir, "cards.json"))
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"key": "K-1",
+		"desc": "url?token=[REDACTED]",
+		"Token": "[REDACTED]",
+		"items": [{"password": "[REDACTED]"}],
+		"body": {"token_value": "[REDACTED]"}
+	}`, string(b))
+
+	// Truncated files still parse, arrays keep first items.
+	b, err = os.ReadFile(filepath.Join(d.dir, "big.json"))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"truncated": true, "size": 302}`, string(b))
+
+	items := make([]string, 0, 30)
+	for i := 0; i < 30; i++ {
+		items = append(items, "item")
+	}
+
+	d.dump("items.json", items)
+
+	b, err = os.ReadFile(filepath.Join(d.dir, "items.json"))
+	require.NoError(t, err)
+	require.LessOrEqual(t, len(b), 200)
+
+	var truncated struct {
+		Truncated bool
+		Size      int
+		Items     []string
+	}
+
+	require.NoError(t, json.Unmarshal(b, &truncated))
+	require.True(t, truncated.Truncated)
+	require.Equal(t, 302, truncated.Size)
+	require.Equal(t, items[:7], truncated.Items)
+}
+
+func TestDumper_addSecrets(t *testing.T) {
+	d := &dumper{}
+
+	for i := 0; i < 3; i++ {
+		d.addSecrets("token", "token-with-suffix")
+	}
+
+	require.Len(t, d.secrets, 2)
+
+	doc, err := d.document("token-with-suffix and token")
+	require.NoError(t, err)
+	require.Equal(t, "[REDACTED] and [REDACTED]", doc)
+}
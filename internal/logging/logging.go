@@ -0,0 +1,148 @@
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Options configures the default logger.
+type Options struct {
+	// Level is one of debug, info, warn or error.
+	Level string
+	// Format is text or json.
+	Format string
+	// File is a path of log file, logs are written to stderr if it's empty.
+	File string
+}
+
+// Setup sets the default logger, messages of standard log package are logged with error level.
+// Returned function closes log file.
+func Setup(opts Options) (func() error, error) {
+	level, err := ParseLevel(opts.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		out     io.Writer = console
+		closeFn           = func() error { return nil }
+	)
+
+	if opts.File != "" {
+		const filePermissions = 0600
+
+		f, err := os.OpenFile(opts.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, filePermissions)
+		if err != nil {
+			return nil, fmt.Errorf("can't open log file: %w", err)
+		}
+
+		out, closeFn = f, f.Close
+	}
+
+	handler, err := newHandler(out, opts.Format, level, opts.File == "")
+	if err != nil {
+		_ = closeFn()
+
+		return nil, err
+	}
+
+	slog.SetDefault(slog.New(handler))
+	log.SetFlags(0)
+	log.SetOutput(slog.NewLogLogger(handler, slog.LevelError).Writer())
+
+	return closeFn, nil
+}
+
+// console writes logs to stderr, they are held while stderr is used by full screen UI.
+var console = &heldWriter{out: os.Stderr}
+
+// heldWriter buffers writes while it's held.
+type heldWriter struct {
+	mu   sync.Mutex
+	out  io.Writer
+	held bool
+	buf  bytes.Buffer
+}
+
+func (w *heldWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.held {
+		return w.buf.Write(p)
+	}
+
+	return w.out.Write(p)
+}
+
+// hold buffers writes until returned function is called, then buffered logs are written out.
+func (w *heldWriter) hold() func() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.held = true
+
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		w.held = false
+		_, _ = w.buf.WriteTo(w.out)
+	}
+}
+
+// HoldConsole holds logs written to stderr, so they don't break full screen UI, until returned function
+// is called. Logs written to file aren't held.
+func HoldConsole() func() {
+	return console.hold()
+}
+
+// ParseLevel returns level by name, empty name is info level.
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+
+	if name == "" {
+		return slog.LevelInfo, nil
+	}
+
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return 0, fmt.Errorf("unknown log level `%s`", name)
+	}
+
+	return level, nil
+}
+
+// newHandler returns handler of the format, time is omitted in text logs written to console.
+func newHandler(out io.Writer, format string, level slog.Level, console bool) (slog.Handler, error) {
+	opts := &slog.HandlerOptions{Level: level}
+
+	switch strings.ToLower(format) {
+	case "", FormatText:
+		if console {
+			opts.ReplaceAttr = func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey && len(groups) == 0 {
+					return slog.Attr{}
+				}
+
+				return a
+			}
+		}
+
+		return slog.NewTextHandler(out, opts), nil
+	case FormatJSON:
+		return slog.NewJSONHandler(out, opts), nil
+	}
+
+	return nil, fmt.Errorf("unknown log format `%s`", format)
+}
@@ -0,0 +1,96 @@
+package logging
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    slog.Level
+		wantErr bool
+	}{
+		{name: "", want: slog.LevelInfo},
+		{name: "debug", want: slog.LevelDebug},
+		{name: "WARN", want: slog.LevelWarn},
+		{name: "error", want: slog.LevelError},
+		{name: "verbose", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLevel(tt.name)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_newHandler(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		console bool
+		want    string
+		wantErr bool
+	}{
+		{name: "console text", format: FormatText, console: true, want: "level=INFO msg=\"Card added\" key=K-1\n"},
+		{name: "json", format: FormatJSON, want: `"level":"INFO","msg":"Card added","key":"K-1"}` + "\n"},
+		{name: "unknown", format: "xml", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			h, err := newHandler(&out, tt.format, slog.LevelInfo, tt.console)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			logger := slog.New(h)
+			logger.Debug("Hidden")
+			logger.Info("Card added", "key", "K-1")
+
+			require.Contains(t, out.String(), tt.want)
+			require.NotContains(t, out.String(), "Hidden")
+
+			if tt.console {
+				require.Equal(t, tt.want, out.String())
+			}
+		})
+	}
+}
+
+func TestHeldWriter_hold(t *testing.T) {
+	var out bytes.Buffer
+
+	w := &heldWriter{out: &out}
+
+	_, err := w.Write([]byte("before\n"))
+	require.NoError(t, err)
+
+	release := w.hold()
+
+	_, err = w.Write([]byte("held\n"))
+	require.NoError(t, err)
+	require.Equal(t, "before\n", out.String())
+
+	release()
+	require.Equal(t, "before\nheld\n", out.String())
+
+	_, err = w.Write([]byte("after\n"))
+	require.NoError(t, err)
+	require.Equal(t, "before\nheld\nafter\n", out.String())
+}
@@ -0,0 +1,281 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	typeCounter   = "counter"
+	typeGauge     = "gauge"
+	typeHistogram = "histogram"
+)
+
+// DefaultBuckets are upper bounds of histogram buckets in seconds suitable for API calls.
+var DefaultBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// Default is a registry of jira2trello metrics, API calls of Jira and Trello clients are counted in it.
+var Default = NewRegistry()
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// Registry keeps metrics and writes them in Prometheus text format.
+type Registry struct {
+	mu       sync.Mutex
+	families map[string]*family
+}
+
+// family is a metric with all its label values.
+type family struct {
+	name    string
+	help    string
+	typ     string
+	labels  []string
+	buckets []float64
+	series  map[string]*series
+}
+
+type series struct {
+	labels string
+	value  float64
+	// counts are numbers of observations in each bucket of histogram, the last bucket is +Inf.
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+func NewRegistry() *Registry {
+	return &Registry{families: map[string]*family{}}
+}
+
+// Counter is a value, which only grows, e.g. number of created cards.
+type Counter struct {
+	r *Registry
+	f *family
+}
+
+// Gauge is a value, which is set to the current state, e.g. number of fetched issues.
+type Gauge struct {
+	r *Registry
+	f *family
+}
+
+// Histogram counts observed values by buckets, e.g. API call latencies.
+type Histogram struct {
+	r *Registry
+	f *family
+}
+
+// NewCounter registers counter with label names, values of labels are passed to Add in the same order.
+func (r *Registry) NewCounter(name, help string, labels ...string) *Counter {
+	return &Counter{r: r, f: r.register(name, help, typeCounter, labels, nil)}
+}
+
+// NewGauge registers gauge with label names.
+func (r *Registry) NewGauge(name, help string, labels ...string) *Gauge {
+	return &Gauge{r: r, f: r.register(name, help, typeGauge, labels, nil)}
+}
+
+// NewHistogram registers histogram with bucket upper bounds in ascending order and label names.
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	return &Histogram{r: r, f: r.register(name, help, typeHistogram, labels, buckets)}
+}
+
+func (r *Registry) register(name, help, typ string, labels []string, buckets []float64) *family {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.families[name]; ok {
+		panic(fmt.Sprintf("metric `%s` is already registered", name))
+	}
+
+	f := &family{name: name, help: help, typ: typ, labels: labels, buckets: buckets, series: map[string]*series{}}
+	r.families[name] = f
+
+	return f
+}
+
+// Inc adds 1 to the counter.
+func (c *Counter) Inc(labels ...string) {
+	c.Add(1, labels...)
+}
+
+// Add adds non-negative value to the counter.
+func (c *Counter) Add(value float64, labels ...string) {
+	if value < 0 {
+		return
+	}
+
+	c.r.mu.Lock()
+	defer c.r.mu.Unlock()
+
+	c.f.get(labels).value += value
+}
+
+// Set sets the gauge value.
+func (g *Gauge) Set(value float64, labels ...string) {
+	g.r.mu.Lock()
+	defer g.r.mu.Unlock()
+
+	g.f.get(labels).value = value
+}
+
+// Observe adds value to the histogram.
+func (h *Histogram) Observe(value float64, labels ...string) {
+	h.r.mu.Lock()
+	defer h.r.mu.Unlock()
+
+	s := h.f.get(labels)
+	s.counts[sort.SearchFloat64s(h.f.buckets, value)]++
+	s.sum += value
+	s.count++
+}
+
+// get returns series of label values, it should be called with registry locked.
+func (f *family) get(values []string) *series {
+	if len(values) != len(f.labels) {
+		panic(fmt.Sprintf("metric `%s` has %d labels, got %d values", f.name, len(f.labels), len(values)))
+	}
+
+	pairs := make([]string, 0, len(values))
+	for i, value := range values {
+		pairs = append(pairs, f.labels[i]+`="`+labelValueReplacer.Replace(value)+`"`)
+	}
+
+	key := strings.Join(pairs, ",")
+
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{labels: key}
+
+		if f.typ == typeHistogram {
+			s.counts = make([]uint64, len(f.buckets)+1)
+		}
+
+		f.series[key] = s
+	}
+
+	return s
+}
+
+// WriteTo writes metrics in Prometheus text format, metrics and their series are sorted by name and labels.
+func (r *Registry) WriteTo(out io.Writer) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]string, 0, len(r.families))
+	for name := range r.families {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	w := &countingWriter{w: bufio.NewWriter(out)}
+
+	for _, name := range names {
+		r.families[name].write(w)
+	}
+
+	if w.err == nil {
+		w.err = w.w.Flush()
+	}
+
+	if w.err != nil {
+		return w.n, fmt.Errorf("can't write metrics: %w", w.err)
+	}
+
+	return w.n, nil
+}
+
+func (f *family) write(w *countingWriter) {
+	w.printf("# HELP %s %s\n# TYPE %s %s\n", f.name, f.help, f.name, f.typ)
+
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := f.series[key]
+
+		if f.typ != typeHistogram {
+			w.printf("%s%s %s\n", f.name, braces(s.labels), formatFloat(s.value))
+
+			continue
+		}
+
+		var cumulative uint64
+
+		for i, count := range s.counts {
+			cumulative += count
+
+			le := math.Inf(1)
+			if i < len(f.buckets) {
+				le = f.buckets[i]
+			}
+
+			w.printf("%s_bucket%s %d\n", f.name, braces(joinLabels(s.labels, `le="`+formatFloat(le)+`"`)), cumulative)
+		}
+
+		w.printf("%s_sum%s %s\n", f.name, braces(s.labels), formatFloat(s.sum))
+		w.printf("%s_count%s %d\n", f.name, braces(s.labels), s.count)
+	}
+}
+
+func joinLabels(labels, label string) string {
+	if labels == "" {
+		return label
+	}
+
+	return labels + "," + label
+}
+
+func braces(labels string) string {
+	if labels == "" {
+		return ""
+	}
+
+	return "{" + labels + "}"
+}
+
+func formatFloat(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// countingWriter counts written bytes and keeps the first write error.
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (w *countingWriter) printf(format string, args ...any) {
+	if w.err != nil {
+		return
+	}
+
+	n, err := fmt.Fprintf(w.w, format, args...)
+	w.n += int64(n)
+	w.err = err
+}
+
+// Handler serves metrics of the registry in Prometheus text format.
+func Handler(r *Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_, _ = r.WriteTo(w)
+	})
+}
@@ -0,0 +1,132 @@
+package metrics
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRegistry_WriteTo(t *testing.T) {
+	r := NewRegistry()
+
+	cards := r.NewCounter("test_cards_total", "Cards.")
+	syncs := r.NewCounter("test_syncs_total", "Syncs.", "type", "result")
+	issues := r.NewGauge("test_issues", "Issues.")
+	duration := r.NewHistogram("test_duration_seconds", "Duration.", []float64{0.1, 1}, "client")
+
+	cards.Inc()
+	cards.Add(2)
+	cards.Add(-1)
+	syncs.Inc("full", "success")
+	syncs.Inc("issue", "failure")
+	syncs.Inc("full", "success")
+	issues.Set(7)
+	issues.Set(5)
+	duration.Observe(0.05, "jira")
+	duration.Observe(0.1, "jira")
+	duration.Observe(0.5, "jira")
+	duration.Observe(3, "jira")
+
+	out := &bytes.Buffer{}
+	n, err := r.WriteTo(out)
+	require.NoError(t, err)
+	require.Equal(t, int64(out.Len()), n)
+	require.Equal(t, `# HELP test_cards_total Cards.
+# TYPE test_cards_total counter
+test_cards_total 3
+# HELP test_duration_seconds Duration.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{client="jira",le="0.1"} 2
+test_duration_seconds_bucket{client="jira",le="1"} 3
+test_duration_seconds_bucket{client="jira",le="+Inf"} 4
+test_duration_seconds_sum{client="jira"} 3.65
+test_duration_seconds_count{client="jira"} 4
+# HELP test_issues Issues.
+# TYPE test_issues gauge
+test_issues 5
+# HELP test_syncs_total Syncs.
+# TYPE test_syncs_total counter
+test_syncs_total{type="full",result="success"} 2
+test_syncs_total{type="issue",result="failure"} 1
+`, out.String())
+}
+
+func TestRegistry_labels(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounter("test_total", "Test.", "name")
+
+	c.Inc("a\"b\\c\nd")
+
+	out := &bytes.Buffer{}
+	_, err := r.WriteTo(out)
+	require.NoError(t, err)
+	require.Contains(t, out.String(), `test_total{name="a\"b\\c\nd"} 1`)
+
+	require.Panics(t, func() {
+		c.Inc()
+	})
+	require.Panics(t, func() {
+		r.NewGauge("test_total", "Duplicate.")
+	})
+}
+
+func TestEndpoint(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/rest/api/2/search", want: "/rest/api/2/search"},
+		{path: "/rest/api/2/issue/JIRA1-1324/transitions", want: "/rest/api/2/issue/{key}/transitions"},
+		{path: "/rest/api/2/issue/10001/worklog", want: "/rest/api/2/issue/{id}/worklog"},
+		{path: "/jira/rest/api/latest/myself", want: "/jira/rest/api/latest/myself"},
+		{path: "/rest/webhooks/1.0/webhook", want: "/rest/webhooks/1.0/webhook"},
+		{path: "/1/cards/12345678909876543219d1cc/idList", want: "/1/cards/{id}/idList"},
+		{path: "/1/boards/5d1b2c3d4e5f6a7b8c9d0e1f/lists", want: "/1/boards/{id}/lists"},
+		{path: "/1/members/me/boards", want: "/1/members/me/boards"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			require.Equal(t, tt.want, Endpoint(tt.path))
+		})
+	}
+}
+
+func TestTransport_RoundTrip(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	cli := &http.Client{Transport: NewTransport("test", http.DefaultTransport)}
+
+	for _, path := range []string{"/1/cards/12345678909876543219d1cc", "/1/cards/12345678909876543219d1cd", "/missing"} {
+		resp, err := cli.Get(ts.URL + path)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	ts.Close()
+
+	_, err := cli.Get(ts.URL + "/down")
+	require.Error(t, err)
+
+	w := httptest.NewRecorder()
+	Handler(Default).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, "text/plain; version=0.0.4; charset=utf-8", w.Header().Get("Content-Type"))
+
+	body := w.Body.String()
+	require.Contains(t, body,
+		`jira2trello_api_requests_total{client="test",method="GET",endpoint="/1/cards/{id}",code="200"} 2`)
+	require.Contains(t, body,
+		`jira2trello_api_requests_total{client="test",method="GET",endpoint="/missing",code="404"} 1`)
+	require.Contains(t, body,
+		`jira2trello_api_requests_total{client="test",method="GET",endpoint="/down",code="error"} 1`)
+	require.Contains(t, body,
+		`jira2trello_api_request_duration_seconds_count{client="test",method="GET",endpoint="/1/cards/{id}"} 2`)
+	require.Contains(t, body, `jira2trello_errors_total{kind="test_api"} 2`)
+}
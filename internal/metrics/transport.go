@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	apiRequests = Default.NewCounter("jira2trello_api_requests_total",
+		"API requests by client, method, endpoint and response status code, code is `error` for network errors.",
+		"client", "method", "endpoint", "code")
+	apiDuration = Default.NewHistogram("jira2trello_api_request_duration_seconds",
+		"Duration of API requests by client, method and endpoint.", DefaultBuckets,
+		"client", "method", "endpoint")
+
+	// Errors counts errors by kind, API request errors are counted as `<client>_api`, e.g. `jira_api`.
+	Errors = Default.NewCounter("jira2trello_errors_total", "Errors by kind.", "kind")
+)
+
+var (
+	// issueKeyRe matches Jira issue key.
+	issueKeyRe = regexp.MustCompile(`^[A-Z][A-Z0-9_]+-\d+$`)
+	// idRe matches numeric IDs, Trello IDs and tokens.
+	idRe = regexp.MustCompile(`^(\d+|[0-9a-fA-F]{24,}|ATTA[0-9a-fA-F]+)$`)
+)
+
+// Transport counts requests and their duration by endpoint of API client.
+type Transport struct {
+	base   http.RoundTripper
+	client string
+}
+
+// NewTransport returns transport, which counts requests of the client, e.g. jira or trello.
+func NewTransport(client string, base http.RoundTripper) *Transport {
+	return &Transport{base: base, client: client}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := Endpoint(req.URL.Path)
+	started := time.Now()
+
+	resp, err := t.base.RoundTrip(req)
+
+	apiDuration.Observe(time.Since(started).Seconds(), t.client, req.Method, endpoint)
+
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+
+	apiRequests.Inc(t.client, req.Method, endpoint, code)
+
+	if err != nil || resp.StatusCode >= http.StatusBadRequest {
+		Errors.Inc(t.client + "_api")
+	}
+
+	// todo: error returned from external package is unwrapped
+	return resp, err
+}
+
+// Endpoint returns path with IDs and issue keys replaced by placeholders, so requests are grouped by API method.
+func Endpoint(path string) string {
+	segments := strings.Split(path, "/")
+
+	for i, segment := range segments {
+		switch {
+		case issueKeyRe.MatchString(segment):
+			segments[i] = "{key}"
+		case isVersion(segments, i):
+		case idRe.MatchString(segment):
+			segments[i] = "{id}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// isVersion reports whether segment of the path is API version, e.g. `/1/cards` of Trello or `/rest/api/2` of Jira.
+func isVersion(segments []string, i int) bool {
+	return i == 1 || i > 0 && segments[i-1] == "api"
+}
@@ -0,0 +1,124 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+const (
+	defaultMaxAttempts  = 4
+	defaultInitialDelay = 500 * time.Millisecond
+	defaultMaxDelay     = 10 * time.Second
+	defaultTimeout      = 30 * time.Second
+)
+
+// Policy configures retries of transient failures, zero values are replaced by defaults.
+type Policy struct {
+	// MaxAttempts is a number of attempts including the first one.
+	MaxAttempts int
+	// InitialDelay is a maximal delay before the first retry, it's doubled on each next retry.
+	InitialDelay time.Duration
+	// MaxDelay limits delay between attempts.
+	MaxDelay time.Duration
+	// Timeout limits each attempt.
+	Timeout time.Duration
+}
+
+// WithDefaults returns copy of the policy with defaults for unset values, nil policy gives default one.
+func (p *Policy) WithDefaults() *Policy {
+	res := Policy{}
+	if p != nil {
+		res = *p
+	}
+
+	if res.MaxAttempts < 1 {
+		res.MaxAttempts = defaultMaxAttempts
+	}
+
+	if res.InitialDelay <= 0 {
+		res.InitialDelay = defaultInitialDelay
+	}
+
+	if res.MaxDelay <= 0 {
+		res.MaxDelay = defaultMaxDelay
+	}
+
+	if res.Timeout <= 0 {
+		res.Timeout = defaultTimeout
+	}
+
+	return &res
+}
+
+// Backoff returns delay before the next attempt using exponential backoff with full jitter.
+func (p *Policy) Backoff(attempt int) time.Duration {
+	delay := p.MaxDelay
+
+	if attempt < 32 {
+		if d := p.InitialDelay << attempt; d > 0 && d < p.MaxDelay {
+			delay = d
+		}
+	}
+
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks error, which shouldn't be retried.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &permanentError{err: err}
+}
+
+// Do calls fn until it succeeds, returns permanent error or attempts are exhausted.
+// Attempt number starting from 0 is passed to fn.
+func (p *Policy) Do(ctx context.Context, fn func(attempt int) error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn(attempt)
+		if err == nil {
+			return nil
+		}
+
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+
+		if attempt+1 >= p.MaxAttempts {
+			return err
+		}
+
+		if err := Sleep(ctx, p.Backoff(attempt)); err != nil {
+			return err
+		}
+	}
+}
+
+// Sleep waits for duration or until context is done.
+func Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
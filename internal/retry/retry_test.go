@@ -0,0 +1,194 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testPolicy() *Policy {
+	return (&Policy{MaxAttempts: 3, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond}).WithDefaults()
+}
+
+func TestPolicy_WithDefaults(t *testing.T) {
+	var p *Policy
+
+	require.Equal(t, &Policy{
+		MaxAttempts:  defaultMaxAttempts,
+		InitialDelay: defaultInitialDelay,
+		MaxDelay:     defaultMaxDelay,
+		Timeout:      defaultTimeout,
+	}, p.WithDefaults())
+
+	p = &Policy{MaxAttempts: 2}
+	require.Equal(t, 2, p.WithDefaults().MaxAttempts)
+	require.Equal(t, defaultTimeout, p.WithDefaults().Timeout)
+}
+
+func TestPolicy_Backoff(t *testing.T) {
+	p := &Policy{InitialDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	for attempt := 0; attempt < 100; attempt++ {
+		delay := p.Backoff(attempt)
+		require.GreaterOrEqual(t, delay, time.Duration(0))
+		require.LessOrEqual(t, delay, time.Second)
+
+		if attempt == 0 {
+			require.LessOrEqual(t, delay, 100*time.Millisecond)
+		}
+	}
+}
+
+func TestPolicy_Do(t *testing.T) {
+	errTest := errors.New("test")
+
+	tests := []struct {
+		name     string
+		fail     int
+		err      error
+		wantErr  error
+		attempts int
+	}{
+		{name: "success", fail: 0, attempts: 1},
+		{name: "success after retries", fail: 2, err: errTest, attempts: 3},
+		{name: "attempts exhausted", fail: 5, err: errTest, wantErr: errTest, attempts: 3},
+		{name: "permanent error", fail: 5, err: Permanent(errTest), wantErr: errTest, attempts: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			err := testPolicy().Do(context.Background(), func(attempt int) error {
+				require.Equal(t, attempts, attempt)
+				attempts++
+
+				if attempt < tt.fail {
+					return tt.err
+				}
+
+				return nil
+			})
+
+			require.Equal(t, tt.attempts, attempts)
+
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.Equal(t, tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTransport_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		statuses    []int
+		rateLimited bool
+		wantStatus  int
+		requests    int
+	}{
+		{name: "get retried", method: http.MethodGet,
+			statuses: []int{http.StatusBadGateway, http.StatusServiceUnavailable}, wantStatus: http.StatusOK, requests: 3},
+		{name: "put retried", method: http.MethodPut,
+			statuses: []int{http.StatusInternalServerError}, wantStatus: http.StatusOK, requests: 2},
+		{name: "attempts exhausted", method: http.MethodGet,
+			statuses:   []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			wantStatus: http.StatusBadGateway, requests: 3},
+		{name: "client error isn't retried", method: http.MethodGet,
+			statuses: []int{http.StatusNotFound}, wantStatus: http.StatusNotFound, requests: 1},
+		{name: "post isn't retried", method: http.MethodPost,
+			statuses: []int{http.StatusBadGateway}, wantStatus: http.StatusBadGateway, requests: 1},
+		{name: "rate limit retried", method: http.MethodGet,
+			statuses: []int{http.StatusTooManyRequests}, wantStatus: http.StatusOK, requests: 2},
+		{name: "rate limit handled by base transport", method: http.MethodGet, rateLimited: true,
+			statuses:   []int{http.StatusTooManyRequests},
+			wantStatus: http.StatusTooManyRequests, requests: 1},
+		{name: "server error retried with rate limit handled by base transport", method: http.MethodGet,
+			rateLimited: true, statuses: []int{http.StatusBadGateway}, wantStatus: http.StatusOK, requests: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bodies := make([]string, 0)
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(b))
+
+				if len(bodies) <= len(tt.statuses) {
+					w.WriteHeader(tt.statuses[len(bodies)-1])
+
+					return
+				}
+
+				_, _ = w.Write([]byte("{}"))
+			}))
+			defer srv.Close()
+
+			transport := NewTransport(http.DefaultTransport, testPolicy())
+			if tt.rateLimited {
+				transport = NewRateLimitedTransport(http.DefaultTransport, testPolicy())
+			}
+
+			cli := &http.Client{Transport: transport}
+
+			req, err := http.NewRequest(tt.method, srv.URL, strings.NewReader("body"))
+			require.NoError(t, err)
+
+			resp, err := cli.Do(req)
+			require.NoError(t, err)
+
+			b, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			require.Equal(t, tt.wantStatus, resp.StatusCode)
+			require.Len(t, bodies, tt.requests)
+
+			if resp.StatusCode == http.StatusOK {
+				require.Equal(t, "{}", string(b))
+			}
+
+			for _, body := range bodies {
+				require.Equal(t, "body", body)
+			}
+		})
+	}
+}
+
+func TestTransport_RoundTrip_timeout(t *testing.T) {
+	var requests int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(200 * time.Millisecond):
+			}
+
+			return
+		}
+
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	p := testPolicy()
+	p.Timeout = 50 * time.Millisecond
+
+	cli := &http.Client{Transport: NewTransport(http.DefaultTransport, p)}
+
+	resp, err := cli.Get(srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
@@ -0,0 +1,127 @@
+package retry
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+)
+
+// Transport retries idempotent requests failed with network error or transient status,
+// each attempt is limited by policy timeout. Other requests are sent once with the timeout,
+// callers are responsible for retrying them safely.
+type Transport struct {
+	base   http.RoundTripper
+	policy *Policy
+	// rateLimited is set when base transport retries requests rejected with 429 status itself.
+	rateLimited bool
+}
+
+func NewTransport(base http.RoundTripper, policy *Policy) *Transport {
+	return &Transport{
+		base:   base,
+		policy: policy.WithDefaults(),
+	}
+}
+
+// NewRateLimitedTransport returns transport, which doesn't retry requests rejected with 429 status,
+// since base transport handles rate limit, so the requests aren't retried twice.
+func NewRateLimitedTransport(base http.RoundTripper, policy *Policy) *Transport {
+	t := NewTransport(base, policy)
+	t.rateLimited = true
+
+	return t
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attempts := t.policy.MaxAttempts
+	if !idempotent(req.Method) || (req.Body != nil && req.GetBody == nil) {
+		attempts = 1
+	}
+
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(req.Context(), t.policy.Timeout)
+
+		resp, err := t.base.RoundTrip(req.WithContext(ctx))
+		if attempt+1 >= attempts || req.Context().Err() != nil || !t.transient(resp, err) {
+			if err != nil {
+				cancel()
+
+				return nil, err
+			}
+
+			resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+
+			return resp, nil
+		}
+
+		if resp != nil {
+			slog.Warn("Retrying request", "method", req.Method, "path", req.URL.Path, "status", resp.StatusCode)
+			resp.Body.Close()
+		} else {
+			slog.Warn("Retrying request", "method", req.Method, "path", req.URL.Path, "error", err)
+		}
+
+		cancel()
+
+		if req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				// todo: error returned from external package is unwrapped
+				return nil, err
+			}
+
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		if err := Sleep(req.Context(), t.policy.Backoff(attempt)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (t *Transport) transient(resp *http.Response, err error) bool {
+	if t.rateLimited && err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		return false
+	}
+
+	return Transient(resp, err)
+}
+
+// Transient reports whether request failed with network error or response status is worth retrying.
+func Transient(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// cancelBody releases attempt context, when response body is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+
+	return err
+}
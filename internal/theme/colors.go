@@ -1,7 +1,8 @@
-package internal
+package theme
 
 const (
 	ColorOff = "\033[0m" // Color Reset
+	Reverse  = "\033[7m" // Reverse video
 
 	Black  = "\033[0;30m" // Regular Black
 	Red    = "\033[0;31m" // Regular Red
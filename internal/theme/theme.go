@@ -0,0 +1,185 @@
+package theme
+
+import (
+	"fmt"
+	"github.com/mattn/go-isatty"
+	"os"
+	"strings"
+)
+
+// Color modes set by --color flag.
+const (
+	ModeAuto   = "auto"
+	ModeAlways = "always"
+	ModeNever  = "never"
+)
+
+// defaultKey is a config key of color used for statuses and types without own color.
+const defaultKey = "default"
+
+// colors are color codes by name, `none` disables color.
+var colors = map[string]string{
+	"none": "",
+
+	"black": Black, "red": Red, "green": Green, "yellow": Yellow,
+	"blue": Blue, "purple": Purple, "cyan": Cyan, "white": White,
+
+	"bold-black": BBlack, "bold-red": BRed, "bold-green": BGreen, "bold-yellow": BYellow,
+	"bold-blue": BBlue, "bold-purple": BPurple, "bold-cyan": BCyan, "bold-white": BWhite,
+
+	"underline-black": UBlack, "underline-red": URed, "underline-green": UGreen, "underline-yellow": UYellow,
+	"underline-blue": UBlue, "underline-purple": UPurple, "underline-cyan": UCyan, "underline-white": UWhite,
+
+	"bright-black": IBlack, "bright-red": IRed, "bright-green": IGreen, "bright-yellow": IYellow,
+	"bright-blue": IBlue, "bright-purple": IPurple, "bright-cyan": ICyan, "bright-white": IWhite,
+
+	"bold-bright-black": BIBlack, "bold-bright-red": BIRed, "bold-bright-green": BIGreen,
+	"bold-bright-yellow": BIYellow, "bold-bright-blue": BIBlue, "bold-bright-purple": BIPurple,
+	"bold-bright-cyan": BICyan, "bold-bright-white": BIWhite,
+}
+
+// Config has color names by Jira status and issue type, e.g. `blocked: bold-red`.
+// Names are case-insensitive, `default` key sets color of the rest.
+type Config struct {
+	Status map[string]string
+	Type   map[string]string
+}
+
+// DefaultConfig returns colors used when they aren't set in config.
+func DefaultConfig() Config {
+	return Config{
+		Status: map[string]string{
+			"dependency":   "red",
+			"blocked":      "red",
+			"todo":         "blue",
+			"in qa review": "cyan",
+			defaultKey:     "yellow",
+		},
+		Type: map[string]string{
+			"story":      "green",
+			"user story": "green",
+			"bug":        "red",
+			defaultKey:   "blue",
+		},
+	}
+}
+
+// Theme colorizes command output, nil or disabled theme returns text as is.
+type Theme struct {
+	enabled bool
+	status  map[string]string
+	types   map[string]string
+}
+
+// New returns theme with user colors on top of default ones.
+func New(cfg Config, enabled bool) (*Theme, error) {
+	def := DefaultConfig()
+
+	status, err := parseColors("status", def.Status, cfg.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	types, err := parseColors("type", def.Type, cfg.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Theme{
+		enabled: enabled,
+		status:  status,
+		types:   types,
+	}, nil
+}
+
+// parseColors returns color codes by lowercase name, colors of the next set override previous ones.
+func parseColors(kind string, sets ...map[string]string) (map[string]string, error) {
+	res := map[string]string{}
+
+	for _, set := range sets {
+		for name, color := range set {
+			code, err := ParseColor(color)
+			if err != nil {
+				return nil, fmt.Errorf("can't parse color of %s `%s`: %w", kind, name, err)
+			}
+
+			res[strings.ToLower(name)] = code
+		}
+	}
+
+	return res, nil
+}
+
+// ParseColor returns color code by name.
+func ParseColor(name string) (string, error) {
+	code, ok := colors[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return "", fmt.Errorf("unknown color `%s`", name)
+	}
+
+	return code, nil
+}
+
+// Enabled reports whether output to the file should be colorized in the mode.
+// Auto mode disables colors, when NO_COLOR environment variable is set or the file isn't a terminal.
+func Enabled(mode string, f *os.File) (bool, error) {
+	switch mode {
+	case ModeAlways:
+		return true, nil
+	case ModeNever:
+		return false, nil
+	case "", ModeAuto:
+		if os.Getenv("NO_COLOR") != "" {
+			return false, nil
+		}
+
+		return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd()), nil
+	}
+
+	return false, fmt.Errorf("unknown color mode `%s`, supported modes: %s, %s, %s",
+		mode, ModeAuto, ModeAlways, ModeNever)
+}
+
+// Paint wraps text with color code, text is returned as is if colors are disabled.
+func (t *Theme) Paint(color, text string) string {
+	if t == nil || !t.enabled || color == "" || text == "" {
+		return text
+	}
+
+	return color + text + ColorOff
+}
+
+// Status returns colorized Jira status.
+func (t *Theme) Status(status string) string {
+	if t == nil {
+		return status
+	}
+
+	return t.Paint(lookup(t.status, status), status)
+}
+
+// Type returns colorized Jira issue type.
+func (t *Theme) Type(issueType string) string {
+	if t == nil {
+		return issueType
+	}
+
+	return t.Paint(lookup(t.types, issueType), issueType)
+}
+
+// DueDate returns due date colorized by whether it's overdue.
+func (t *Theme) DueDate(date string, overdue bool) string {
+	if overdue {
+		return t.Paint(Red, date)
+	}
+
+	return t.Paint(Green, date)
+}
+
+func lookup(colors map[string]string, name string) string {
+	if color, ok := colors[strings.ToLower(name)]; ok {
+		return color
+	}
+
+	return colors[defaultKey]
+}
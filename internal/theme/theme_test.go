@@ -0,0 +1,85 @@
+package theme
+
+import (
+	"github.com/stretchr/testify/require"
+	"os"
+	"testing"
+)
+
+func TestTheme(t *testing.T) {
+	th, err := New(Config{
+		Status: map[string]string{"blocked": "bold-red", "default": "none"},
+		Type:   map[string]string{"Epic": "purple"},
+	}, true)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "user status", got: th.Status("Blocked"), want: BRed + "Blocked" + ColorOff},
+		{name: "default status", got: th.Status("ToDo"), want: Blue + "ToDo" + ColorOff},
+		{name: "user default status", got: th.Status("Closed"), want: "Closed"},
+		{name: "user type", got: th.Type("Epic"), want: Purple + "Epic" + ColorOff},
+		{name: "default type", got: th.Type("Task"), want: Blue + "Task" + ColorOff},
+		{name: "overdue", got: th.DueDate("18 May 20", true), want: Red + "18 May 20" + ColorOff},
+		{name: "empty", got: th.DueDate("", false), want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.got)
+		})
+	}
+}
+
+func TestTheme_disabled(t *testing.T) {
+	th, err := New(Config{}, false)
+	require.NoError(t, err)
+	require.Equal(t, "Bug", th.Type("Bug"))
+
+	th = nil
+	require.Equal(t, "Bug", th.Type("Bug"))
+	require.Equal(t, "[ OK ]", th.Paint(Green, "[ OK ]"))
+}
+
+func TestNew_unknownColor(t *testing.T) {
+	_, err := New(Config{Type: map[string]string{"bug": "crimson"}}, true)
+	require.EqualError(t, err, "can't parse color of type `bug`: unknown color `crimson`")
+}
+
+func TestEnabled(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	tests := []struct {
+		name    string
+		mode    string
+		noColor string
+		want    bool
+		wantErr bool
+	}{
+		{name: "always", mode: ModeAlways, noColor: "1", want: true},
+		{name: "never", mode: ModeNever, want: false},
+		{name: "auto not terminal", mode: ModeAuto, want: false},
+		{name: "auto no color", mode: ModeAuto, noColor: "1", want: false},
+		{name: "unknown", mode: "sometimes", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+
+			got, err := Enabled(tt.mode, f)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -22,14 +22,27 @@ THE SOFTWARE.
 package trello
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
+	"github.com/Brialius/jira2trello/internal/logging"
+	"github.com/Brialius/jira2trello/internal/metrics"
+	"github.com/Brialius/jira2trello/internal/retry"
 	"github.com/adlio/trello"
-	"os"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 )
 
-const MaxDescLength = 10000
+const (
+	MaxDescLength = 10000
+
+	defaultTimeout = 2 * time.Minute
+)
+
+// httpStatusRe matches status of failed request in error message of Trello client.
+var httpStatusRe = regexp.MustCompile(`(?m)^(\d{3}): `)
 
 type Client struct {
 	*Config
@@ -43,18 +56,27 @@ func NewClient(cfg *Config) *Client {
 	}
 }
 
-func (t *Client) Connect() error {
+func (t *Client) Connect(ctx context.Context) error {
+	logging.AddSecrets(t.APIKey, t.Token, t.Secret)
+
 	t.cli = trello.NewClient(t.APIKey, t.Token)
+	t.cli.Client = &http.Client{
+		Transport: retry.NewRateLimitedTransport(
+			newRateLimitTransport(metrics.NewTransport("trello", http.DefaultTransport), t.APIKey, t.Token), t.Retry),
+	}
 	if len(t.Board) > 0 {
-		return t.SetBoard()
+		return t.SetBoard(ctx)
 	}
 
 	return nil
 }
 
-func (t *Client) GetBoards() (map[string]*Board, error) {
+func (t *Client) GetBoards(ctx context.Context) (map[string]*Board, error) {
+	cli, _, cancel := t.withContext(ctx)
+	defer cancel()
+
 	res := map[string]*Board{}
-	boards, err := t.cli.GetMyBoards(trello.Defaults())
+	boards, err := cli.GetMyBoards(trello.Defaults())
 
 	if err != nil {
 		// todo: error returned from external package is unwrapped
@@ -69,14 +91,17 @@ func (t *Client) GetBoards() (map[string]*Board, error) {
 		}
 	}
 
-	t.writeToJSONFile(boards, "debug_debug_boards.json")
-	t.writeToJSONFile(res, "debug_debug_boards_result.json")
+	t.writeToJSONFile(boards, "boards.json")
+	t.writeToJSONFile(res, "boards_result.json")
 
 	return res, nil
 }
 
-func (t *Client) GetLists() (map[string]*List, error) {
-	lists, err := t.board.GetLists(trello.Defaults())
+func (t *Client) GetLists(ctx context.Context) (map[string]*List, error) {
+	_, board, cancel := t.withContext(ctx)
+	defer cancel()
+
+	lists, err := board.GetLists(trello.Defaults())
 	if err != nil {
 		return nil, err
 	}
@@ -89,16 +114,19 @@ func (t *Client) GetLists() (map[string]*List, error) {
 		}
 	}
 
-	t.writeToJSONFile(lists, "debug_lists.json")
-	t.writeToJSONFile(res, "debug_lists_result.json")
+	t.writeToJSONFile(lists, "lists.json")
+	t.writeToJSONFile(res, "lists_result.json")
 
 	return res, nil
 }
 
-func (t *Client) GetLabels() (map[string]*Label, error) {
+func (t *Client) GetLabels(ctx context.Context) (map[string]*Label, error) {
+	_, board, cancel := t.withContext(ctx)
+	defer cancel()
+
 	res := map[string]*Label{}
 
-	labels, err := t.board.GetLabels(trello.Defaults())
+	labels, err := board.GetLabels(trello.Defaults())
 	if err != nil {
 		return nil, err
 	}
@@ -110,14 +138,59 @@ func (t *Client) GetLabels() (map[string]*Label, error) {
 		}
 	}
 
-	t.writeToJSONFile(labels, "debug_labels.json")
-	t.writeToJSONFile(res, "debug_labels_result.json")
+	t.writeToJSONFile(labels, "labels.json")
+	t.writeToJSONFile(res, "labels_result.json")
 
 	return res, nil
 }
 
-func (t *Client) GetUserJiraCards() ([]*Card, error) {
-	cards, err := t.board.GetCards(trello.Defaults())
+func (t *Client) GetMembers(ctx context.Context) (map[string]*Member, error) {
+	_, board, cancel := t.withContext(ctx)
+	defer cancel()
+
+	res := map[string]*Member{}
+
+	members, err := board.GetMembers(trello.Defaults())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		res[member.Username] = &Member{
+			Name:     member.Username,
+			FullName: member.FullName,
+			ID:       member.ID,
+			Email:    member.Email,
+		}
+	}
+
+	t.writeToJSONFile(members, "members.json")
+	t.writeToJSONFile(res, "members_result.json")
+
+	return res, nil
+}
+
+// GetUserJiraCards returns cards with Jira label assigned to current user.
+func (t *Client) GetUserJiraCards(ctx context.Context) ([]*Card, error) {
+	return t.GetMemberJiraCards(ctx, t.UserID)
+}
+
+// GetMemberJiraCards returns cards with Jira label assigned to the member.
+func (t *Client) GetMemberJiraCards(ctx context.Context, memberID string) ([]*Card, error) {
+	return t.getJiraCards(ctx, func(card *trello.Card) bool {
+		return slices.Contains(card.IDMembers, memberID)
+	})
+}
+
+// GetJiraCards returns all cards with Jira label on the board.
+func (t *Client) GetJiraCards(ctx context.Context) ([]*Card, error) {
+	return t.getJiraCards(ctx, func(card *trello.Card) bool {
+		return true
+	})
+}
+
+func (t *Client) getJiraCards(ctx context.Context, filter func(card *trello.Card) bool) ([]*Card, error) {
+	cards, err := t.getCards(ctx, trello.Arguments{"customFieldItems": "true"}, "cards.json", true)
 	if err != nil {
 		return nil, err
 	}
@@ -125,77 +198,272 @@ func (t *Client) GetUserJiraCards() ([]*Card, error) {
 	res := make([]*Card, 0, len(cards))
 
 	for _, card := range cards {
-		if strings.Contains(strings.Join(card.IDMembers, ","), t.UserID) &&
-			strings.Contains(strings.Join(card.IDLabels, ","), t.Labels.Jira) {
-			res = append(res, &Card{
-				ID:        card.ID,
-				Name:      card.Name,
-				ListID:    card.IDList,
-				List:      GetListNameByID(card.IDList, t.Lists),
-				Key:       strings.TrimSpace(strings.Split(card.Name, "|")[0]),
-				Desc:      card.Desc,
-				IDLabels:  &card.IDLabels,
-				IDMembers: strings.Join(card.IDMembers, ","),
-			})
+		if marker := card.Marker; filter(card.card) && (marker == nil || marker.Type != MarkerTypeEpic) {
+			res = append(res, card.Card)
 		}
 	}
 
-	t.writeToJSONFile(cards, "debug_cards.json")
-	t.writeToJSONFile(res, "debug_cards_result.json")
+	t.writeToJSONFile(res, "cards_result.json")
 
 	return res, nil
 }
 
-func (t *Client) writeToJSONFile(value any, fileName string) {
-	if t.Debug {
-		const filePermissions = 0600
+// GetEpicCards returns epic cards with their checklists.
+func (t *Client) GetEpicCards(ctx context.Context) ([]*Card, error) {
+	cards, err := t.getCards(ctx, trello.Arguments{"checklists": "all"}, "epic_cards.json", true)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*Card, 0)
+
+	for _, card := range cards {
+		if card.Marker == nil || card.Marker.Type != MarkerTypeEpic {
+			continue
+		}
+
+		for _, checklist := range card.card.Checklists {
+			if checklist.Name == EpicChecklist {
+				card.ChecklistID = checklist.ID
+
+				for _, item := range checklist.CheckItems {
+					card.CheckItems = append(card.CheckItems, &CheckItem{
+						ID:       item.ID,
+						Name:     item.Name,
+						Complete: item.State == "complete",
+					})
+				}
+			}
+		}
 
-		//nolint:errchkjson
-		b, _ := json.MarshalIndent(value, "", "  ")
-		err := os.WriteFile(fileName, b, filePermissions)
+		res = append(res, card.Card)
+	}
+
+	return res, nil
+}
+
+type boardCard struct {
+	*Card
+	card *trello.Card
+}
 
-		if err != nil {
-			fmt.Printf("can't write debug file: %s", err)
+// GetBoardCards returns all open cards of the board.
+func (t *Client) GetBoardCards(ctx context.Context) ([]*Card, error) {
+	cards, err := t.getCards(ctx, trello.Defaults(), "board_cards.json", false)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*Card, 0, len(cards))
+
+	for _, card := range cards {
+		res = append(res, card.Card)
+	}
+
+	return res, nil
+}
+
+// getCards returns board cards, only cards with Jira label are returned if jiraOnly is set.
+func (t *Client) getCards(ctx context.Context, args trello.Arguments, debugFile string,
+	jiraOnly bool) ([]*boardCard, error) {
+	_, board, cancel := t.withContext(ctx)
+	defer cancel()
+
+	cards, err := board.GetCards(args)
+	if err != nil {
+		// todo: error returned from external package is unwrapped
+		return nil, err
+	}
+
+	t.writeToJSONFile(cards, debugFile)
+
+	res := make([]*boardCard, 0, len(cards))
+
+	for _, card := range cards {
+		if !jiraOnly || strings.Contains(strings.Join(card.IDLabels, ","), t.Labels.Jira) {
+			marker, _ := ParseMarker(card.Desc)
+			res = append(res, &boardCard{card: card, Card: &Card{
+				ID:           card.ID,
+				Name:         card.Name,
+				ListID:       card.IDList,
+				List:         GetListNameByID(card.IDList, t.Lists),
+				Key:          CardKey(card.Name, card.Desc, marker),
+				Desc:         card.Desc,
+				IDLabels:     &card.IDLabels,
+				IDMembers:    strings.Join(card.IDMembers, ","),
+				Marker:       marker,
+				CustomFields: cardCustomFields(card),
+				Details:      card.Badges.CheckItems + card.Badges.Attachments + card.Badges.Comments,
+			}})
 		}
 	}
+
+	return res, nil
+}
+
+// writeToJSONFile writes debug file, when debug is enabled.
+func (t *Client) writeToJSONFile(value any, fileName string) {
+	if t.Debug {
+		logging.Dump(fileName, value)
+	}
 }
 
-func (t *Client) CreateCard(card *Card) error {
+func (t *Client) CreateCard(ctx context.Context, card *Card) error {
+	cli, _, cancel := t.withContext(ctx)
+	defer cancel()
+
 	desc := card.Desc
 
 	if len(desc) > MaxDescLength {
 		desc = strings.ToValidUTF8(card.Desc[:MaxDescLength], "") + "..."
 	}
 
-	return t.cli.CreateCard(&trello.Card{
+	if card.Marker != nil {
+		desc = SetMarker(desc, card.Marker)
+	}
+
+	newCard := &trello.Card{
 		Name:      card.Name,
 		IDLabels:  *card.IDLabels,
 		IDList:    card.ListID,
 		IDMembers: strings.Split(card.IDMembers, ","),
 		Desc:      desc,
-	}, trello.Defaults())
-}
+	}
 
-func (t *Client) MoveCardToList(cardID, listID string) error {
-	card, err := t.cli.GetCard(cardID, trello.Defaults())
+	// Card creation isn't idempotent, so before each retry the list is checked for the card,
+	// which could be created by the failed attempt.
+	err := t.Retry.WithDefaults().Do(ctx, func(attempt int) error {
+		if attempt > 0 {
+			created, err := findCreatedCard(cli, newCard, card.Marker)
+			if err != nil {
+				return err
+			}
+
+			if created != nil {
+				newCard.ID = created.ID
+
+				return nil
+			}
+		}
+
+		err := cli.CreateCard(newCard, trello.Defaults())
+		if err != nil && !transient(err) {
+			return retry.Permanent(err)
+		}
+
+		return err
+	})
 	if err != nil {
+		// todo: error returned from external package is unwrapped
 		return err
 	}
 
-	return card.MoveToList(listID, trello.Defaults())
+	card.ID = newCard.ID
+
+	return nil
 }
 
-func (t *Client) UpdateCardLabels(cardID, labels string) error {
-	card, err := t.cli.GetCard(cardID, trello.Defaults())
+// findCreatedCard returns card from the list with the same name and marker key.
+func findCreatedCard(cli *trello.Client, newCard *trello.Card, marker *Marker) (*trello.Card, error) {
+	cards := make([]*trello.Card, 0)
+
+	if err := cli.Get("lists/"+newCard.IDList+"/cards", trello.Defaults(), &cards); err != nil {
+		// todo: error returned from external package is unwrapped
+		return nil, err
+	}
+
+	for _, card := range cards {
+		if card.Name != newCard.Name {
+			continue
+		}
+
+		if marker != nil {
+			if m, ok := ParseMarker(card.Desc); !ok || m.Key != marker.Key {
+				continue
+			}
+		}
+
+		return card, nil
+	}
+
+	return nil, nil
+}
+
+// transient reports whether error returned from Trello client isn't a client error with 4xx status,
+// so request can be retried.
+func transient(err error) bool {
+	m := httpStatusRe.FindStringSubmatch(err.Error())
+	if m == nil {
+		return true
+	}
+
+	status, _ := strconv.Atoi(m[1])
+
+	return status < 400 || status >= 500
+}
+
+func (t *Client) MoveCardToList(ctx context.Context, cardID, listID string) error {
+	return t.updateCard(ctx, cardID, trello.Arguments{"idList": listID})
+}
+
+func (t *Client) UpdateCardLabels(ctx context.Context, cardID, labels string) error {
+	return t.updateCard(ctx, cardID, trello.Arguments{"idLabels": labels})
+}
+
+func (t *Client) UpdateCardMembers(ctx context.Context, cardID, members string) error {
+	return t.updateCard(ctx, cardID, trello.Arguments{"idMembers": members})
+}
+
+// GetCard returns card by ID.
+func (t *Client) GetCard(ctx context.Context, cardID string) (*Card, error) {
+	cli, _, cancel := t.withContext(ctx)
+	defer cancel()
+
+	card, err := cli.GetCard(cardID, trello.Defaults())
 	if err != nil {
-		return err
+		// todo: error returned from external package is unwrapped
+		return nil, err
 	}
 
-	return card.Update(trello.Arguments{"idLabels": labels})
+	marker, _ := ParseMarker(card.Desc)
+
+	return &Card{
+		ID:        card.ID,
+		Name:      card.Name,
+		ListID:    card.IDList,
+		List:      GetListNameByID(card.IDList, t.Lists),
+		Key:       CardKey(card.Name, card.Desc, marker),
+		Desc:      card.Desc,
+		IDLabels:  &card.IDLabels,
+		IDMembers: strings.Join(card.IDMembers, ","),
+		Marker:    marker,
+	}, nil
+}
+
+func (t *Client) UpdateCardName(ctx context.Context, cardID, name string) error {
+	return t.updateCard(ctx, cardID, trello.Arguments{"name": name})
+}
+
+func (t *Client) UpdateCardDesc(ctx context.Context, cardID, desc string) error {
+	return t.updateCard(ctx, cardID, trello.Arguments{"desc": desc})
+}
+
+// updateCard updates card fields without getting the card first.
+func (t *Client) updateCard(ctx context.Context, cardID string, args trello.Arguments) error {
+	cli, _, cancel := t.withContext(ctx)
+	defer cancel()
+
+	var card trello.Card
+
+	// todo: error returned from external package is unwrapped
+	return cli.Put("cards/"+cardID, args, &card)
 }
 
-func (t *Client) SetBoard() error {
-	board, err := t.cli.GetBoard(t.Board, trello.Defaults())
+func (t *Client) SetBoard(ctx context.Context) error {
+	cli, _, cancel := t.withContext(ctx)
+	defer cancel()
+
+	board, err := cli.GetBoard(t.Board, trello.Defaults())
 	if err != nil {
 		return err
 	}
@@ -205,35 +473,96 @@ func (t *Client) SetBoard() error {
 	return nil
 }
 
-func (t *Client) GetSelfMemberID() (string, error) {
-	member, err := t.cli.GetMember("me", trello.Defaults())
+func (t *Client) GetSelfMemberID(ctx context.Context) (string, error) {
+	cli, _, cancel := t.withContext(ctx)
+	defer cancel()
+
+	member, err := cli.GetMember("me", trello.Defaults())
 	if err != nil {
 		return "", err
 	}
 
-	t.writeToJSONFile(member, "debug_self_id.json")
+	t.writeToJSONFile(member, "self_id.json")
 
 	return member.ID, nil
 }
 
+// withContext returns client and board bound to context, which is limited by configured call timeout.
+func (t *Client) withContext(ctx context.Context) (*trello.Client, *trello.Board, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout())
+	cli := t.cli.WithContext(ctx)
+
+	var board *trello.Board
+
+	if t.board != nil {
+		b := *t.board
+		b.SetClient(cli)
+		board = &b
+	}
+
+	return cli, board, cancel
+}
+
+// timeout returns configured call timeout, which includes retries.
+func (t *Client) timeout() time.Duration {
+	if t.Timeout > 0 {
+		return t.Timeout
+	}
+
+	return defaultTimeout
+}
+
 func (t *Client) GetConfig() *Config {
 	return t.Config
 }
 
-func (t *Client) ArchiveAllCardsInList(listID string) error {
-	cards, err := t.board.GetCards(trello.Defaults())
+// ArchiveAllCardsInList archives cards in the list and returns archived cards,
+// on error cards archived before the failure are returned as well.
+func (t *Client) ArchiveAllCardsInList(ctx context.Context, listID string) ([]*Card, error) {
+	_, board, cancel := t.withContext(ctx)
+	defer cancel()
+
+	cards, err := board.GetCards(trello.Defaults())
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	res := make([]*Card, 0)
+
 	for _, card := range cards {
 		if card.IDList == listID {
 			if err := card.Archive(); err != nil {
-				return err
+				return res, err
 			}
+
+			res = append(res, &Card{
+				ID:     card.ID,
+				Name:   card.Name,
+				ListID: card.IDList,
+			})
 		}
 	}
 
-	return nil
+	return res, nil
+}
+
+func (t *Client) ArchiveCard(ctx context.Context, cardID string) error {
+	cli, _, cancel := t.withContext(ctx)
+	defer cancel()
+
+	card := &trello.Card{ID: cardID}
+	card.SetClient(cli)
+
+	return card.Archive()
+}
+
+func (t *Client) UnarchiveCard(ctx context.Context, cardID string) error {
+	cli, _, cancel := t.withContext(ctx)
+	defer cancel()
+
+	card := &trello.Card{ID: cardID}
+	card.SetClient(cli)
+
+	return card.Unarchive()
 }
@@ -0,0 +1,77 @@
+package trello
+
+import (
+	"context"
+	"errors"
+	"github.com/Brialius/jira2trello/internal/retry"
+	"github.com/adlio/trello"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_CreateCard(t *testing.T) {
+	tests := []struct {
+		name    string
+		created bool
+		posts   int
+		wantID  string
+	}{
+		{name: "card created by failed attempt", created: true, posts: 1, wantID: "created"},
+		{name: "card created by retry", created: false, posts: 2, wantID: "retried"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			posts := 0
+			existing := `[{"id":"other","name":"AAA-1 | Task","desc":""}]`
+
+			if tt.created {
+				existing = `[{"id":"created","name":"AAA-1 | Task","desc":"` +
+					`[jira2trello]: <https://jira/browse/AAA-1> \"key=AAA-1\""}]`
+			}
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/cards":
+					posts++
+
+					if posts == 1 {
+						w.WriteHeader(http.StatusBadGateway)
+
+						return
+					}
+
+					_, _ = w.Write([]byte(`{"id":"retried"}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/lists/list/cards":
+					_, _ = w.Write([]byte(existing))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer srv.Close()
+
+			cli := NewClient(&Config{Retry: &retry.Policy{InitialDelay: time.Millisecond}})
+			cli.cli = trello.NewClient("key", "token")
+			cli.cli.BaseURL = srv.URL
+
+			card := &Card{
+				Name:     "AAA-1 | Task",
+				ListID:   "list",
+				IDLabels: &[]string{},
+				Marker:   &Marker{Key: "AAA-1", Link: "https://jira/browse/AAA-1"},
+			}
+
+			require.NoError(t, cli.CreateCard(context.Background(), card))
+			require.Equal(t, tt.wantID, card.ID)
+			require.Equal(t, tt.posts, posts)
+		})
+	}
+}
+
+func Test_transient(t *testing.T) {
+	require.True(t, transient(errors.New("HTTP request failure on https://api.trello.com/1/cards: EOF")))
+	require.True(t, transient(errors.New("HTTP request failure on https://api.trello.com/1/cards:\n502: Bad Gateway")))
+	require.False(t, transient(errors.New("HTTP request failure on https://api.trello.com/1/cards:\n400: invalid value")))
+}
@@ -1,11 +1,32 @@
 package trello
 
+import (
+	"github.com/Brialius/jira2trello/internal/retry"
+	"time"
+)
+
 type Config struct {
 	APIKey string
 	Token  string
+	// Secret of API key, it verifies signature of webhook requests.
+	Secret string
 	Board  string
 	UserID string
 	Lists  *Lists
 	Labels *Labels
-	Debug  bool
+	// Members maps Jira users to card members, only current user is added to cards if it's not enabled.
+	Members *MemberMapping
+	// Shared board is used by several users, sync manages all Jira cards on the board owned by current user.
+	Shared bool
+	// CustomFields enables syncing of Jira fields to card custom fields.
+	CustomFields bool
+	// Epics configures grouping of cards by Jira epic, cards aren't grouped if it's not set.
+	Epics *Epics
+	// Workers is a number of cards updated in parallel during sync.
+	Workers int
+	// Timeout limits each API call including retries, default is 2 minutes.
+	Timeout time.Duration
+	// Retry configures retries of failed API requests, defaults are used if it's not set.
+	Retry *retry.Policy
+	Debug bool
 }
@@ -0,0 +1,188 @@
+package trello
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/adlio/trello"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+const (
+	CustomFieldNumber = "number"
+	CustomFieldText   = "text"
+	CustomFieldList   = "list"
+)
+
+// CustomField is a board custom field, list options are mapped by text to option ID.
+type CustomField struct {
+	ID      string
+	Name    string
+	Type    string
+	Options map[string]string
+}
+
+func newCustomField(field *trello.CustomField) *CustomField {
+	res := &CustomField{
+		ID:      field.ID,
+		Name:    field.Name,
+		Type:    field.Type,
+		Options: map[string]string{},
+	}
+
+	for _, option := range field.Options {
+		res.Options[option.Value.Text] = option.ID
+	}
+
+	return res
+}
+
+// GetCustomFields returns board custom fields by name.
+func (t *Client) GetCustomFields(ctx context.Context) (map[string]*CustomField, error) {
+	_, board, cancel := t.withContext(ctx)
+	defer cancel()
+
+	fields, err := board.GetCustomFields(trello.Defaults())
+	if err != nil {
+		// todo: error returned from external package is unwrapped
+		return nil, err
+	}
+
+	res := map[string]*CustomField{}
+
+	for _, field := range fields {
+		res[field.Name] = newCustomField(field)
+	}
+
+	t.writeToJSONFile(fields, "custom_fields.json")
+
+	return res, nil
+}
+
+// CreateCustomField creates board custom field shown on card front.
+func (t *Client) CreateCustomField(ctx context.Context, name, fieldType string) (*CustomField, error) {
+	cli, _, cancel := t.withContext(ctx)
+	defer cancel()
+
+	field := &trello.CustomField{}
+
+	err := cli.Post("customFields", trello.Arguments{
+		"idModel":           t.board.ID,
+		"modelType":         "board",
+		"name":              name,
+		"type":              fieldType,
+		"pos":               "bottom",
+		"display_cardFront": "true",
+	}, field)
+	if err != nil {
+		// todo: error returned from external package is unwrapped
+		return nil, err
+	}
+
+	return newCustomField(field), nil
+}
+
+// CreateCustomFieldOption adds option to list custom field and returns option ID.
+func (t *Client) CreateCustomFieldOption(ctx context.Context, fieldID, text string) (string, error) {
+	var option trello.CustomFieldOption
+
+	body := map[string]any{
+		"value": map[string]string{"text": text},
+		"pos":   "bottom",
+	}
+
+	if err := t.sendJSON(ctx, http.MethodPost, "customFields/"+fieldID+"/options", body, &option); err != nil {
+		return "", fmt.Errorf("can't create option `%s`: %w", text, err)
+	}
+
+	return option.ID, nil
+}
+
+// SetCardCustomField sets custom field value of the card, value is option ID for list fields,
+// empty value clears the field.
+func (t *Client) SetCardCustomField(ctx context.Context, cardID string, field *CustomField, value string) error {
+	var body map[string]any
+
+	switch {
+	case field.Type == CustomFieldList:
+		body = map[string]any{"idValue": value}
+	case value == "":
+		body = map[string]any{"value": ""}
+	default:
+		body = map[string]any{"value": map[string]string{field.Type: value}}
+	}
+
+	path := "cards/" + cardID + "/customField/" + field.ID + "/item"
+
+	if err := t.sendJSON(ctx, http.MethodPut, path, body, nil); err != nil {
+		return fmt.Errorf("can't set custom field `%s`: %w", field.Name, err)
+	}
+
+	return nil
+}
+
+// sendJSON sends request with JSON body, which isn't supported by trello package.
+func (t *Client) sendJSON(ctx context.Context, method, path string, body, target any) error {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout())
+	defer cancel()
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("can't encode request: %w", err)
+	}
+
+	params := url.Values{}
+	params.Set("key", t.cli.Key)
+	params.Set("token", t.cli.Token)
+
+	req, err := http.NewRequestWithContext(ctx, method, t.cli.BaseURL+"/"+path+"?"+params.Encode(), bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("can't create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.cli.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("can't send request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("can't read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, respBody)
+	}
+
+	if target == nil {
+		return nil
+	}
+
+	return json.Unmarshal(respBody, target)
+}
+
+// cardCustomFields returns card custom field values by field ID, value of list field is option ID.
+func cardCustomFields(card *trello.Card) map[string]string {
+	res := map[string]string{}
+
+	for _, item := range card.CustomFieldItems {
+		if item.IDValue != "" {
+			res[item.IDCustomField] = item.IDValue
+
+			continue
+		}
+
+		if value := item.Value.Get(); value != nil {
+			res[item.IDCustomField] = fmt.Sprint(value)
+		}
+	}
+
+	return res
+}
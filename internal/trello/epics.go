@@ -0,0 +1,71 @@
+package trello
+
+import (
+	"context"
+	"github.com/adlio/trello"
+)
+
+// EpicChecklist is a name of epic card checklist with epic tasks.
+const EpicChecklist = "Tasks"
+
+// CreateLabel creates board label.
+func (t *Client) CreateLabel(ctx context.Context, name, color string) (*Label, error) {
+	_, board, cancel := t.withContext(ctx)
+	defer cancel()
+
+	label := &trello.Label{Name: name, Color: color}
+
+	if err := board.CreateLabel(label, trello.Defaults()); err != nil {
+		// todo: error returned from external package is unwrapped
+		return nil, err
+	}
+
+	return &Label{
+		Name: label.Name,
+		ID:   label.ID,
+	}, nil
+}
+
+// AddCheckItem adds item to epic card checklist, checklist is created if it doesn't exist.
+func (t *Client) AddCheckItem(ctx context.Context, card *Card, name string) error {
+	cli, _, cancel := t.withContext(ctx)
+	defer cancel()
+
+	if card.ChecklistID == "" {
+		checklist, err := cli.CreateChecklist(&trello.Card{ID: card.ID}, EpicChecklist, trello.Defaults())
+		if err != nil {
+			// todo: error returned from external package is unwrapped
+			return err
+		}
+
+		card.ChecklistID = checklist.ID
+	}
+
+	checklist := &trello.Checklist{ID: card.ChecklistID}
+
+	item, err := cli.CreateCheckItem(checklist, name, trello.Defaults())
+	if err != nil {
+		// todo: error returned from external package is unwrapped
+		return err
+	}
+
+	card.CheckItems = append(card.CheckItems, &CheckItem{ID: item.ID, Name: item.Name})
+
+	return nil
+}
+
+// SetCheckItemState marks epic card checklist item complete or incomplete.
+func (t *Client) SetCheckItemState(ctx context.Context, cardID, itemID string, complete bool) error {
+	cli, _, cancel := t.withContext(ctx)
+	defer cancel()
+
+	state := "incomplete"
+	if complete {
+		state = "complete"
+	}
+
+	var item trello.CheckItem
+
+	// todo: error returned from external package is unwrapped
+	return cli.Put("cards/"+cardID+"/checkItem/"+itemID, trello.Arguments{"state": state}, &item)
+}
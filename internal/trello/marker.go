@@ -0,0 +1,100 @@
+package trello
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	markerLabel    = "jira2trello"
+	MarkerTypeEpic = "epic"
+)
+
+var (
+	// markerRe matches marker line, which is a markdown link reference definition, so it isn't rendered by Trello.
+	markerRe   = regexp.MustCompile(`(?m)^\[` + markerLabel + `\]: <([^>]*)> "([^"]*)"[ \t]*$`)
+	keyRe      = regexp.MustCompile(`^[A-Z][A-Z0-9_]*-[0-9]+$`)
+	jiraLinkRe = regexp.MustCompile(`(?m)^Jira link: \S*/browse/([A-Z][A-Z0-9_]*-[0-9]+)\s*$`)
+)
+
+// Marker links card to Jira issue, it's stored in the hidden card description footer.
+type Marker struct {
+	Key   string
+	Link  string
+	Owner string
+	// Type is MarkerTypeEpic for epic cards, it's empty for task cards.
+	Type string
+}
+
+func (m *Marker) String() string {
+	attrs := []string{"key=" + m.Key}
+
+	if m.Owner != "" {
+		attrs = append(attrs, "owner="+m.Owner)
+	}
+
+	if m.Type != "" {
+		attrs = append(attrs, "type="+m.Type)
+	}
+
+	return fmt.Sprintf("[%s]: <%s> %q", markerLabel, m.Link, strings.Join(attrs, " "))
+}
+
+// ParseMarker returns marker from card description.
+func ParseMarker(desc string) (*Marker, bool) {
+	match := markerRe.FindStringSubmatch(desc)
+	if match == nil {
+		return nil, false
+	}
+
+	m := &Marker{Link: match[1]}
+
+	for _, attr := range strings.Fields(match[2]) {
+		name, value, _ := strings.Cut(attr, "=")
+
+		switch name {
+		case "key":
+			m.Key = value
+		case "owner":
+			m.Owner = value
+		case "type":
+			m.Type = value
+		}
+	}
+
+	if m.Key == "" {
+		return nil, false
+	}
+
+	return m, true
+}
+
+// SetMarker returns card description with the marker, existing marker is replaced.
+func SetMarker(desc string, m *Marker) string {
+	desc = strings.TrimRight(markerRe.ReplaceAllString(desc, ""), "\n")
+
+	if desc == "" {
+		return m.String()
+	}
+
+	return desc + "\n\n" + m.String()
+}
+
+// CardKey returns Jira key of the card, it's taken from the marker, cards created before markers were added
+// are linked by name prefix or by Jira link in description.
+func CardKey(name, desc string, marker *Marker) string {
+	if marker != nil {
+		return marker.Key
+	}
+
+	if key := strings.TrimSpace(strings.Split(name, "|")[0]); keyRe.MatchString(key) {
+		return key
+	}
+
+	if match := jiraLinkRe.FindStringSubmatch(desc); match != nil {
+		return match[1]
+	}
+
+	return ""
+}
@@ -0,0 +1,108 @@
+package trello
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseMarker(t *testing.T) {
+	tests := []struct {
+		name   string
+		desc   string
+		want   *Marker
+		wantOK bool
+	}{
+		{
+			name:   "marker with owner",
+			desc:   "Task description\n\n[jira2trello]: <https://jira/browse/JIRA1-1> \"key=JIRA1-1 owner=abc\"",
+			want:   &Marker{Key: "JIRA1-1", Link: "https://jira/browse/JIRA1-1", Owner: "abc"},
+			wantOK: true,
+		},
+		{
+			name:   "marker without owner",
+			desc:   "[jira2trello]: <https://jira/browse/JIRA1-2> \"key=JIRA1-2\"\n",
+			want:   &Marker{Key: "JIRA1-2", Link: "https://jira/browse/JIRA1-2"},
+			wantOK: true,
+		},
+		{
+			name:   "epic marker",
+			desc:   "[jira2trello]: <https://jira/browse/EP-1> \"key=EP-1 owner=abc type=epic\"",
+			want:   &Marker{Key: "EP-1", Link: "https://jira/browse/EP-1", Owner: "abc", Type: MarkerTypeEpic},
+			wantOK: true,
+		},
+		{
+			name: "no marker",
+			desc: "Task description\nJira link: https://jira/browse/JIRA1-1",
+		},
+		{
+			name: "marker without key",
+			desc: "[jira2trello]: <https://jira/browse/JIRA1-2> \"owner=abc\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseMarker(tt.desc)
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSetMarker(t *testing.T) {
+	m := &Marker{Key: "JIRA1-1", Link: "https://jira/browse/JIRA1-1", Owner: "abc"}
+	want := "Task description\n\n[jira2trello]: <https://jira/browse/JIRA1-1> \"key=JIRA1-1 owner=abc\""
+
+	require.Equal(t, want, SetMarker("Task description", m))
+	require.Equal(t, want, SetMarker(SetMarker("Task description\n", &Marker{Key: "JIRA1-1", Link: "x"}), m))
+	require.Equal(t, m.String(), SetMarker("", m))
+
+	got, ok := ParseMarker(SetMarker("Task description", m))
+	require.True(t, ok)
+	require.Equal(t, m, got)
+}
+
+func TestCard_OwnedBy(t *testing.T) {
+	require.True(t, (&Card{IDMembers: "abc,def"}).OwnedBy("def"))
+	require.False(t, (&Card{IDMembers: "abc"}).OwnedBy("def"))
+	require.True(t, (&Card{IDMembers: "abc", Marker: &Marker{Key: "K-1", Owner: "def"}}).OwnedBy("def"))
+	require.False(t, (&Card{IDMembers: "def", Marker: &Marker{Key: "K-1", Owner: "abc"}}).OwnedBy("def"))
+	require.True(t, (&Card{IDMembers: "def", Marker: &Marker{Key: "K-1"}}).OwnedBy("def"))
+}
+
+func TestCardKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		card   string
+		desc   string
+		marker *Marker
+		want   string
+	}{
+		{
+			name:   "marker",
+			card:   "Renamed card",
+			marker: &Marker{Key: "JIRA1-1"},
+			want:   "JIRA1-1",
+		},
+		{
+			name: "name prefix",
+			card: "JIRA1-2 | Summary | with pipe",
+			want: "JIRA1-2",
+		},
+		{
+			name: "jira link in description",
+			card: "Renamed card",
+			desc: "Task description\nJira link: https://jira-site/browse/JIRA1-3\nType: Bug",
+			want: "JIRA1-3",
+		},
+		{
+			name: "unknown",
+			card: "Renamed | card",
+			desc: "Task description",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, CardKey(tt.card, tt.desc, tt.marker))
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package trello
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
 
 type Lists struct {
 	Todo   string
@@ -28,6 +32,21 @@ type Card struct {
 	Desc      string
 	IDLabels  *[]string
 	IDMembers string
+	Marker    *Marker
+	// CustomFields are values by field ID, value of list field is option ID.
+	CustomFields map[string]string
+	// Details is a number of checklist items, attachments and comments, it's set for board cards.
+	Details int
+	// ChecklistID and CheckItems are set for epic cards only.
+	ChecklistID string
+	CheckItems  []*CheckItem
+}
+
+// CheckItem is an item of epic card checklist.
+type CheckItem struct {
+	ID       string
+	Name     string
+	Complete bool
 }
 
 type Board struct {
@@ -50,6 +69,40 @@ type Member struct {
 	Name     string
 	FullName string
 	ID       string
+	Email    string
+}
+
+// OwnedBy returns true if card is owned by the member, cards without owner in marker are owned by their members.
+func (c *Card) OwnedBy(memberID string) bool {
+	if c.Marker != nil && c.Marker.Owner != "" {
+		return c.Marker.Owner == memberID
+	}
+
+	return slices.Contains(strings.Split(c.IDMembers, ","), memberID)
+}
+
+const (
+	EpicsModeLabel = "label"
+	EpicsModeCard  = "card"
+)
+
+// Epics configures grouping of cards by Jira epic.
+type Epics struct {
+	// Mode is EpicsModeLabel for label per epic or EpicsModeCard for epic card with checklist of its tasks.
+	Mode string
+	// List is list ID of epic cards, Todo list is used by default.
+	List string
+}
+
+// MemberMapping configures syncing of Jira users to card members.
+type MemberMapping struct {
+	Enabled bool
+	// Roles of Jira users added to card: assignee, reporter, watchers.
+	Roles []string
+	// AutoMatch matches Jira users to board members by `email` or `fullname`, when user isn't mapped explicitly.
+	AutoMatch string
+	// Users maps Jira username to Trello member username or ID.
+	Users map[string]string
 }
 
 func (c *Card) String() string {
@@ -0,0 +1,146 @@
+package trello
+
+import (
+	"context"
+	"github.com/Brialius/jira2trello/internal/retry"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Trello rate limits, see https://developer.atlassian.com/cloud/trello/guides/rest-api/rate-limits/
+const (
+	keyRequestsLimit   = 300
+	tokenRequestsLimit = 100
+	rateLimitWindow    = 10 * time.Second
+
+	maxRateLimitRetries = 5
+	defaultRetryAfter   = time.Second
+)
+
+var (
+	limitersMu sync.Mutex
+	// limiters are shared by all clients with the same API key or token.
+	limiters = map[string]*windowLimiter{}
+)
+
+// windowLimiter allows limit requests in sliding window.
+type windowLimiter struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	times  []time.Time
+}
+
+func getLimiter(name string, limit int) *windowLimiter {
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+
+	l, ok := limiters[name]
+	if !ok {
+		l = &windowLimiter{limit: limit, window: rateLimitWindow}
+		limiters[name] = l
+	}
+
+	return l
+}
+
+// wait blocks until request is allowed by the limiter or context is done.
+func (l *windowLimiter) wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+
+		for len(l.times) > 0 && now.Sub(l.times[0]) >= l.window {
+			l.times = l.times[1:]
+		}
+
+		if len(l.times) < l.limit {
+			l.times = append(l.times, now)
+			l.mu.Unlock()
+
+			return nil
+		}
+
+		delay := l.window - now.Sub(l.times[0])
+		l.mu.Unlock()
+
+		if err := retry.Sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// rateLimitTransport keeps requests within per key and per token limits and retries requests
+// rejected with 429 status after delay from Retry-After header.
+type rateLimitTransport struct {
+	base     http.RoundTripper
+	limiters []*windowLimiter
+}
+
+func newRateLimitTransport(base http.RoundTripper, apiKey, token string) *rateLimitTransport {
+	return &rateLimitTransport{
+		base: base,
+		limiters: []*windowLimiter{
+			getLimiter("key:"+apiKey, keyRequestsLimit),
+			getLimiter("token:"+token, tokenRequestsLimit),
+		},
+	}
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		for _, l := range t.limiters {
+			if err := l.wait(req.Context()); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.base.RoundTrip(req)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests || attempt >= maxRateLimitRetries {
+			return resp, err
+		}
+
+		if req.Body != nil {
+			if req.GetBody == nil {
+				return resp, nil
+			}
+
+			body, err := req.GetBody()
+			if err != nil {
+				return resp, nil
+			}
+
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		delay := retryAfter(resp.Header.Get("Retry-After"))
+		resp.Body.Close()
+
+		slog.Debug("Trello rate limit exceeded", "path", req.URL.Path, "delay", delay)
+
+		if err := retry.Sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryAfter returns delay from Retry-After header, which is seconds or HTTP date.
+func retryAfter(header string) time.Duration {
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay
+		}
+
+		return 0
+	}
+
+	return defaultRetryAfter
+}
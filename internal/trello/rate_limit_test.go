@@ -0,0 +1,73 @@
+package trello
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWindowLimiter_wait(t *testing.T) {
+	l := &windowLimiter{limit: 2, window: 50 * time.Millisecond}
+	ctx := context.Background()
+
+	start := time.Now()
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, l.wait(ctx))
+	}
+
+	require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	l = &windowLimiter{limit: 1, window: time.Minute}
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	require.NoError(t, l.wait(ctx))
+	require.ErrorIs(t, l.wait(ctx), context.Canceled)
+}
+
+func TestRateLimitTransport_RoundTrip(t *testing.T) {
+	requests := 0
+	bodies := make([]string, 0)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+
+		if requests < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+
+			return
+		}
+
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	cli := &http.Client{Transport: newRateLimitTransport(http.DefaultTransport, "test-key", "test-token")}
+
+	req, err := http.NewRequest(http.MethodPut, srv.URL, strings.NewReader(`{"value":""}`))
+	require.NoError(t, err)
+
+	resp, err := cli.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, 3, requests)
+	require.Equal(t, []string{`{"value":""}`, `{"value":""}`, `{"value":""}`}, bodies)
+}
+
+func Test_retryAfter(t *testing.T) {
+	require.Equal(t, 3*time.Second, retryAfter("3"))
+	require.Equal(t, defaultRetryAfter, retryAfter(""))
+	require.Equal(t, time.Duration(0), retryAfter(time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)))
+}
@@ -0,0 +1,62 @@
+package trello
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/adlio/trello"
+)
+
+// RegisterWebhook registers webhook of the board, nothing is changed if it's already registered.
+// Trello checks the callback URL with HEAD request, so it should be served before registration.
+func (t *Client) RegisterWebhook(ctx context.Context, callbackURL string) error {
+	cli, board, cancel := t.withContext(ctx)
+	defer cancel()
+
+	if board == nil {
+		return errors.New("board isn't set")
+	}
+
+	var webhooks []*trello.Webhook
+	if err := cli.Get("tokens/"+t.Token+"/webhooks", trello.Defaults(), &webhooks); err != nil {
+		return fmt.Errorf("can't get webhooks: %w", err)
+	}
+
+	for _, webhook := range webhooks {
+		if webhook.IDModel == board.ID && webhook.CallbackURL == callbackURL {
+			return nil
+		}
+	}
+
+	if err := cli.CreateWebhook(&trello.Webhook{
+		IDModel:     board.ID,
+		Description: "jira2trello",
+		CallbackURL: callbackURL,
+	}); err != nil {
+		return fmt.Errorf("can't create webhook: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyWebhookSignature reports whether webhook request is signed with the secret,
+// Trello signs request body followed by callback URL with HMAC-SHA1.
+func VerifyWebhookSignature(secret, callbackURL string, body []byte, signature string) bool {
+	if secret == "" {
+		return false
+	}
+
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(body)
+	mac.Write([]byte(callbackURL))
+
+	want, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(mac.Sum(nil), want)
+}
@@ -0,0 +1,86 @@
+package trello
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/adlio/trello"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	const callbackURL = "https://j2t.example.com/webhooks/trello"
+
+	body := []byte(`{"action":{"type":"updateCard"}}`)
+	// Signature is made with: printf '%s%s' "$body" "$callbackURL" | openssl dgst -sha1 -hmac secret -binary | base64
+	signature := "7GYc5vFwVVl38iCZ2/g/2A1zh6k="
+
+	tests := []struct {
+		name        string
+		secret      string
+		callbackURL string
+		body        []byte
+		signature   string
+		want        bool
+	}{
+		{name: "valid", secret: "secret", callbackURL: callbackURL, body: body, signature: signature, want: true},
+		{name: "wrong secret", secret: "other", callbackURL: callbackURL, body: body, signature: signature},
+		{name: "no secret", callbackURL: callbackURL, body: body, signature: signature},
+		{name: "other callback", secret: "secret", callbackURL: callbackURL + "/", body: body, signature: signature},
+		{name: "changed body", secret: "secret", callbackURL: callbackURL, body: body[1:], signature: signature},
+		{name: "invalid signature", secret: "secret", callbackURL: callbackURL, body: body, signature: "%%%"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, VerifyWebhookSignature(tt.secret, tt.callbackURL, tt.body, tt.signature))
+		})
+	}
+}
+
+func TestClient_RegisterWebhook(t *testing.T) {
+	tests := []struct {
+		name        string
+		callbackURL string
+		wantCreated bool
+	}{
+		{name: "registered", callbackURL: "https://j2t.example.com/webhooks/trello"},
+		{name: "new", callbackURL: "https://other.example.com/webhooks/trello", wantCreated: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created := make([]string, 0)
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/tokens/token/webhooks":
+					_ = json.NewEncoder(w).Encode([]*trello.Webhook{
+						{ID: "1", IDModel: "other", CallbackURL: tt.callbackURL},
+						{ID: "2", IDModel: "board", CallbackURL: "https://j2t.example.com/webhooks/trello"},
+					})
+				case r.Method == http.MethodPost && r.URL.Path == "/webhooks":
+					require.Equal(t, "board", r.URL.Query().Get("idModel"))
+					created = append(created, r.URL.Query().Get("callbackURL"))
+
+					_, _ = w.Write([]byte(`{"id":"3"}`))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+			}))
+			defer srv.Close()
+
+			cli := trello.NewClient("key", "token")
+			cli.BaseURL = srv.URL
+			c := &Client{Config: &Config{Token: "token"}, cli: cli, board: &trello.Board{ID: "board"}}
+
+			require.NoError(t, c.RegisterWebhook(context.Background(), tt.callbackURL))
+
+			if tt.wantCreated {
+				require.Equal(t, []string{tt.callbackURL}, created)
+			} else {
+				require.Empty(t, created)
+			}
+		})
+	}
+}
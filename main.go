@@ -24,11 +24,13 @@ package main
 import (
 	"fmt"
 	"github.com/Brialius/jira2trello/cmd"
+	"os"
 )
 
 var version = "v0.0.0-dev"
 
 func main() {
-	fmt.Printf("jira2trello %s\n", version)
+	// Banner is printed to stderr, so stdout has only command output, e.g. json.
+	_, _ = fmt.Fprintf(os.Stderr, "jira2trello %s\n", version)
 	cmd.Execute(version)
 }